@@ -0,0 +1,44 @@
+package timefy
+
+// Normalize returns the clock time brought back into its valid ranges (0-23 hours, 0-59 minutes and
+// 0-59 seconds), along with the number of days carried over by the normalization.
+//
+// Overflowing components are carried into the next larger unit, so 25:70:00 becomes 02:10:00 with a
+// carry of +1 day. Negative components borrow from the larger units in the same way, so -01:00:00
+// becomes 23:00:00 with a carry of -1 day.
+//
+// Returns:
+//
+//   - A ClockTime value in which every component is within its valid range.
+//
+//   - An integer value representing the number of days carried over (positive or negative).
+//
+// Example:
+//
+//	c, days := ClockTime{Hour: 25, Minute: 70}.Normalize() // This will return 02:10:00, 1.
+func (c ClockTime) Normalize() (ClockTime, int) {
+	const day = 24 * 60 * 60
+	total := c.Hour*60*60 + c.Minute*60 + c.Second
+	days := total / day
+	total %= day
+	if total < 0 {
+		total += day
+		days--
+	}
+	return ClockTime{Hour: total / 3600, Minute: total % 3600 / 60, Second: total % 60}, days
+}
+
+// Valid reports whether every component of the clock time is within its valid range:
+// 0-23 for the hour, 0-59 for the minute and 0-59 for the second.
+//
+// Returns:
+//
+//   - A boolean value: true if the clock time is valid; false otherwise.
+//
+// Example:
+//
+//	ok := ClockTime{Hour: 9, Minute: 30}.Valid() // This will return true.
+//	ok = ClockTime{Hour: 25}.Valid()             // This will return false.
+func (c ClockTime) Valid() bool {
+	return c.Hour >= 0 && c.Hour < 24 && c.Minute >= 0 && c.Minute < 60 && c.Second >= 0 && c.Second < 60
+}
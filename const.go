@@ -1,6 +1,7 @@
 package timefy
 
 import (
+	"errors"
 	"regexp"
 	"time"
 )
@@ -8,6 +9,8 @@ import (
 type TimeRFC string
 type TimeFormatRFC string
 type ZoneRFC string
+type Period string
+type EpochUnit string
 
 // WeekStartDay set week start day, default is sunday
 var WeekStartDay = time.Sunday
@@ -15,6 +18,28 @@ var WeekStartDay = time.Sunday
 // DefaultConfig default config
 var DefaultConfig *Config
 
+// NowFunc returns the current time used by the package helpers, default is time.Now
+var NowFunc = time.Now
+
+// EnglishLocale built-in English labels used by TimeAgo and TimeUntil
+var EnglishLocale = Locale{
+	JustNow: "just now",
+	Soon:    "in a few seconds",
+	Past:    "%s ago",
+	Future:  "in %s",
+	Second:  Plural{One: "%d second", Other: "%d seconds"},
+	Minute:  Plural{One: "%d minute", Other: "%d minutes"},
+	Hour:    Plural{One: "%d hour", Other: "%d hours"},
+	Day:     Plural{One: "%d day", Other: "%d days"},
+	Week:    Plural{One: "%d week", Other: "%d weeks"},
+	Month:   Plural{One: "%d month", Other: "%d months"},
+	Year:    Plural{One: "%d year", Other: "%d years"},
+}
+
+// ErrZeroTime is returned by Parse when the configuration rejects zero times (see Config.WithRejectZero)
+// and the parsed result is the zero time.Time
+var ErrZeroTime = errors.New("parsed time is zero")
+
 const (
 	// Time in format 15:04:05,
 	//	e.g., 13:45:30
@@ -177,6 +202,56 @@ const (
 	// Time in format 2006-01-02T15:04:05UTC-07:00:00,
 	//	e.g., 2023-08-15T13:45:30UTC-07:00:00
 	TimeFormat20060102150405Z070000UTCRFC3339 TimeFormatRFC = "2006-01-02T15:04:05UTC-07:00:00"
+
+	// Time in iCalendar (RFC 5545) UTC format 20060102T150405Z,
+	//	e.g., 20230815T134530Z
+	TimeFormat20060102T150405ZICS TimeFormatRFC = "20060102T150405Z"
+
+	// Time in iCalendar (RFC 5545) local (floating) format 20060102T150405,
+	//	e.g., 20230815T134530
+	TimeFormat20060102T150405ICS TimeFormatRFC = "20060102T150405"
+)
+
+// Period constants representing the calendar units used to bucket time values.
+const (
+	// PeriodMinute represents a one-minute period, e.g., 13:45:00 - 13:45:59
+	PeriodMinute Period = "minute"
+
+	// PeriodHour represents a one-hour period, e.g., 13:00:00 - 13:59:59
+	PeriodHour Period = "hour"
+
+	// PeriodDay represents a one-day period, e.g., 2023-08-15 00:00:00 - 23:59:59
+	PeriodDay Period = "day"
+
+	// PeriodWeek represents a one-week period, starting at the configured week start day.
+	PeriodWeek Period = "week"
+
+	// PeriodMonth represents a one-month period, e.g., 2023-08-01 - 2023-08-31
+	PeriodMonth Period = "month"
+
+	// PeriodQuarter represents a three-month period, e.g., 2023-07-01 - 2023-09-30
+	PeriodQuarter Period = "quarter"
+
+	// PeriodHalf represents a six-month period, e.g., 2023-07-01 - 2023-12-31
+	PeriodHalf Period = "half"
+
+	// PeriodYear represents a one-year period, e.g., 2023-01-01 - 2023-12-31
+	PeriodYear Period = "year"
+)
+
+// EpochUnit constants representing the units in which a time can be expressed relative to the Unix epoch.
+const (
+	// EpochSeconds represents seconds since January 1, 1970 UTC, e.g., 1692107130
+	EpochSeconds EpochUnit = "seconds"
+
+	// EpochMillis represents milliseconds since January 1, 1970 UTC, e.g., 1692107130123
+	EpochMillis EpochUnit = "millis"
+
+	// EpochMicros represents microseconds since January 1, 1970 UTC, e.g., 1692107130123456
+	EpochMicros EpochUnit = "micros"
+
+	// EpochNanos represents nanoseconds since January 1, 1970 UTC, e.g., 1692107130123456789
+	EpochNanos EpochUnit = "nanos"
 )
 
 // Timezone constants representing default timezones for specific regions.
@@ -321,12 +396,12 @@ const (
 var (
 	// TimeFormatRegexp is a regular expression that matches various time formats such as:
 	// 	15:04:05, 15:04:05.000, 15:04:05.000000, 15, 2017-01-01 15:04, 2021-07-20T00:59:10Z,
-	// 	2021-07-20T00:59:10+08:00, 2021-07-20T00:00:10-07:00, etc.
-	TimeFormatRegexp = regexp.MustCompile(`(\s+|^\s*|T)\d{1,2}((:\d{1,2})*|((:\d{1,2}){2}\.(\d{3}|\d{6}|\d{9})))(\s*$|[Z+-])`)
+	// 	2021-07-20T00:59:10+08:00, 2021-07-20T00:00:10-07:00, 3:04 PM, 3:04:05 pm, etc.
+	TimeFormatRegexp = regexp.MustCompile(`(\s+|^\s*|T)\d{1,2}((:\d{1,2})*|((:\d{1,2}){2}\.(\d{3}|\d{6}|\d{9})))(\s*(AM|PM|am|pm))?(\s*$|[Z+-])`)
 
 	// TimeOnlyRegexp is a regular expression that matches time formats such as:
-	// 	15:04:05, 15, 15:04:05.000, 15:04:05.000000, etc.
-	TimeOnlyRegexp = regexp.MustCompile(`^\s*\d{1,2}((:\d{1,2})*|((:\d{1,2}){2}\.(\d{3}|\d{6}|\d{9})))\s*$`)
+	// 	15:04:05, 15, 15:04:05.000, 15:04:05.000000, 3:04 PM, 3:04:05 pm, 3:04PM, etc.
+	TimeOnlyRegexp = regexp.MustCompile(`^\s*\d{1,2}((:\d{1,2})*|((:\d{1,2}){2}\.(\d{3}|\d{6}|\d{9})))(\s*(AM|PM|am|pm))?\s*$`)
 )
 
 var (
@@ -368,6 +443,10 @@ var (
 		time.RFC3339,                              // RFC 3339 format, e.g., 2023-08-15T13:45:30Z
 		time.RFC3339Nano,                          // RFC 3339 format with nanoseconds, e.g., 2023-08-15T13:45:30.123456789Z
 		time.Kitchen,                              // Kitchen format, e.g., 1:45PM
+		"3:04 PM",                                 // 12-hour time with meridiem, e.g., 1:45 PM
+		"3:04:05 PM",                              // 12-hour time with seconds and meridiem, e.g., 1:45:30 PM
+		"3:04 pm",                                 // 12-hour time with lowercase meridiem, e.g., 1:45 pm
+		"3:04:05 pm",                              // 12-hour time with seconds and lowercase meridiem, e.g., 1:45:30 pm
 		time.Stamp,                                // Stamp format, e.g., Aug 15 13:45:30
 		time.StampMilli,                           // Stamp format with milliseconds, e.g., Aug 15 13:45:30.123
 		time.StampMicro,                           // Stamp format with microseconds, e.g., Aug 15 13:45:30.123456
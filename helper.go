@@ -0,0 +1,418 @@
+package timefy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// formatUint128 returns the representation of the unsigned 128-bit integer hi*2^64 + lo in the given base
+// (2 to 36), with lowercase letters for the digit values >= 10, like strconv.FormatUint.
+func formatUint128(hi, lo uint64, base int) string {
+	if hi == 0 {
+		return strconv.FormatUint(lo, base)
+	}
+	var buf [128]byte
+	i := len(buf)
+	for hi != 0 || lo != 0 {
+		var digit uint64
+		hi, digit = bits.Div64(0, hi, uint64(base))
+		lo, digit = bits.Div64(digit, lo, uint64(base))
+		i--
+		buf[i] = "0123456789abcdefghijklmnopqrstuvwxyz"[digit]
+	}
+	return string(buf[i:])
+}
+
+// dateKey returns a comparable key identifying the calendar date (year, month, day) of the
+// provided time value `v` in its own location, e.g., 2023-08-15 13:45:30 => 20230815.
+func dateKey(v time.Time) int {
+	y, m, d := v.Date()
+	return y*10000 + int(m)*100 + d
+}
+
+// startOfDay returns the first instant of the date year-month-day (normalized like time.Date) in the location `loc`.
+// This is midnight, except in zones where a DST transition skips midnight, in which case it is the instant
+// of the transition (e.g., 01:00 after clocks jump from 00:00), rather than the hour before that time.Date yields.
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	noon := time.Date(year, month, day, 12, 0, 0, 0, loc)
+	year, month, day = noon.Date()
+	v := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if dateKey(v) != dateKey(noon) {
+		_, before := v.Zone()
+		_, after := noon.Zone()
+		v = v.Add(time.Duration(after-before) * time.Second)
+	}
+	return v
+}
+
+// holidaySet builds a lookup set of the calendar dates in `holidays`, each converted to the
+// location `loc` before its date is extracted, so the time-of-day components are ignored.
+func holidaySet(holidays []time.Time, loc *time.Location) map[int]struct{} {
+	set := make(map[int]struct{}, len(holidays))
+	for _, h := range holidays {
+		set[dateKey(h.In(loc))] = struct{}{}
+	}
+	return set
+}
+
+// jsonFormat returns the layout used to encode the Timex instance to JSON: the `JSONFormat` of its
+// configuration, or time.RFC3339Nano (which keeps sub-second precision) when it is unset.
+func (t *Timex) jsonFormat() string {
+	if t.Config != nil && t.JSONFormat != "" {
+		return t.JSONFormat
+	}
+	return time.RFC3339Nano
+}
+
+// weekdayOf returns the start of the day of the given weekday within the Monday-based week
+// containing `v`, preserving the location of `v`. The target date is computed before the start of
+// the day, so that a midnight skipped by DST does not move the result to the previous day.
+func weekdayOf(v time.Time, weekday time.Weekday) time.Time {
+	current := (int(v.Weekday()) + 6) % 7
+	target := (int(weekday) + 6) % 7
+	y, m, d := v.Date()
+	return startOfDay(y, m, d+target-current, v.Location())
+}
+
+// completedYears returns the number of full years elapsed between `birth` and `at`, evaluated on
+// calendar dates in the location of `birth`. A February 29th anniversary falls on February 28th
+// in non-leap years.
+func completedYears(birth, at time.Time) int {
+	at = at.In(birth.Location())
+	years := at.Year() - birth.Year()
+	month, day := birth.Month(), birth.Day()
+	if month == time.February && day == 29 && !IsLeapYear(at.Year()) {
+		day = 28
+	}
+	if at.Month() < month || (at.Month() == month && at.Day() < day) {
+		years--
+	}
+	return years
+}
+
+// clockOn returns the instant at the given wall clock on the date year-month-day in the location `loc`.
+// A wall clock skipped by a DST transition resolves to the same elapsed time after midnight (e.g., 02:30
+// on a spring-forward day becomes 03:30), and a duplicated wall clock resolves to its earlier instant.
+func clockOn(year int, month time.Month, day int, loc *time.Location, hour, min, sec int) time.Time {
+	v := time.Date(year, month, day, hour, min, sec, 0, loc)
+	if h, m, s := v.Clock(); h != hour || m != min || s != sec {
+		offset := time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec)*time.Second
+		return startOfDay(year, month, day, loc).Add(offset)
+	}
+	return v
+}
+
+// now returns the current time as reported by the package clock (NowFunc).
+func now() time.Time {
+	return NowFunc()
+}
+
+// fixedClock is a Clock that always reports the same instant.
+type fixedClock time.Time
+
+// Now returns the frozen instant of the clock.
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// clockTimeOn returns the instant at the clock time `c` on the calendar date of `v`, in the location of `v`.
+func clockTimeOn(v time.Time, c ClockTime) time.Time {
+	y, m, d := v.Date()
+	return clockOn(y, m, d, v.Location(), c.Hour, c.Minute, c.Second)
+}
+
+// relativeUnit enumerates the units used to describe a duration in relative terms (e.g., "5 minutes ago").
+type relativeUnit int
+
+const (
+	unitNow relativeUnit = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+// relativeSpan reduces the span from `from` to `to` to the largest single unit that describes it, along
+// with the whole number of that unit. Spans under a minute (including negative ones) map to unitNow.
+// Minutes, hours, days and weeks are counted in elapsed time, while months and years are counted on
+// the calendar with DiffParts. Spans are reported in total months until they reach 24 months, so that
+// e.g. 45 days is 1 month, 400 days is 13 months and 800 days is 2 years.
+func relativeSpan(from, to time.Time) (int, relativeUnit) {
+	d := to.Sub(from)
+	switch {
+	case d < time.Minute:
+		return 0, unitNow
+	case d < time.Hour:
+		return int(d / time.Minute), unitMinute
+	case d < 24*time.Hour:
+		return int(d / time.Hour), unitHour
+	}
+	years, months, _, _, _, _ := DiffParts(from, to)
+	months += years * 12
+	days := int(d / (24 * time.Hour))
+	switch {
+	case months >= 24:
+		return months / 12, unitYear
+	case months > 0:
+		return months, unitMonth
+	case days < 7:
+		return days, unitDay
+	default:
+		return days / 7, unitWeek
+	}
+}
+
+// shortUnits holds the compact suffixes of each relativeUnit, e.g., "m" for minutes and "mo" for months.
+var shortUnits = map[relativeUnit]string{
+	unitMinute: "m",
+	unitHour:   "h",
+	unitDay:    "d",
+	unitWeek:   "w",
+	unitMonth:  "mo",
+	unitYear:   "y",
+}
+
+// shortSpan formats the span from `from` to `to` in compact form (e.g., "5m", "2h", "3d"), or returns
+// "now" when the span is under a minute.
+func shortSpan(from, to time.Time) string {
+	n, unit := relativeSpan(from, to)
+	if unit == unitNow {
+		return "now"
+	}
+	return strconv.Itoa(n) + shortUnits[unit]
+}
+
+// sinceMidnight returns the nominal offset of the clock time `c` from the start of a day.
+func sinceMidnight(c ClockTime) time.Duration {
+	return time.Duration(c.Hour)*time.Hour + time.Duration(c.Minute)*time.Minute + time.Duration(c.Second)*time.Second
+}
+
+// windowedDuration sums the time between `a` and `b` that falls within the daily window [dayStart, dayEnd),
+// evaluated on calendar days in the location `loc` (nil keeps the location of `a`). Weekends are skipped
+// when `weekdaysOnly` is true. The result is negative when `b` is before `a`.
+func windowedDuration(a, b time.Time, dayStart, dayEnd ClockTime, loc *time.Location, weekdaysOnly bool) time.Duration {
+	if loc == nil {
+		loc = a.Location()
+	}
+	a, b = a.In(loc), b.In(loc)
+	sign := time.Duration(1)
+	if b.Before(a) {
+		a, b = b, a
+		sign = -1
+	}
+	var total time.Duration
+	y, m, d := a.Date()
+	last := dateKey(b)
+	for day := time.Date(y, m, d, 12, 0, 0, 0, loc); dateKey(day) <= last; day = day.AddDate(0, 0, 1) {
+		if weekdaysOnly && IsWeekend(day) {
+			continue
+		}
+		open, close := clockTimeOn(day, dayStart), clockTimeOn(day, dayEnd)
+		if open.Before(a) {
+			open = a
+		}
+		if close.After(b) {
+			close = b
+		}
+		if close.After(open) {
+			total += close.Sub(open)
+		}
+	}
+	return sign * total
+}
+
+// format returns the singular template of the plural pair when `n` is 1, and the plural one otherwise,
+// with `n` substituted for its "%d" verb.
+func (p Plural) format(n int) string {
+	if n == 1 {
+		return fmt.Sprintf(p.One, n)
+	}
+	return fmt.Sprintf(p.Other, n)
+}
+
+// unit returns the plural pair of the locale describing the relative unit `u`.
+func (l Locale) unit(u relativeUnit) Plural {
+	switch u {
+	case unitMinute:
+		return l.Minute
+	case unitHour:
+		return l.Hour
+	case unitDay:
+		return l.Day
+	case unitWeek:
+		return l.Week
+	case unitMonth:
+		return l.Month
+	default:
+		return l.Year
+	}
+}
+
+// relative describes the span from `from` to `to` with the labels of the locale, wrapping the amount in
+// the `template` (the locale's Past or Future phrasing). Under a second, it returns JustNow. Under a minute,
+// it returns the amount of seconds when `exact` is true and the locale defines a Second unit, and the
+// `vague` label otherwise (falling back to JustNow when that label is empty).
+func (l Locale) relative(from, to time.Time, template, vague string, exact bool) string {
+	d := to.Sub(from)
+	switch {
+	case d < time.Second:
+		return l.JustNow
+	case d < time.Minute && exact && l.Second != (Plural{}):
+		return fmt.Sprintf(template, l.Second.format(int(d/time.Second)))
+	case d < time.Minute:
+		if vague == "" {
+			return l.JustNow
+		}
+		return vague
+	}
+	n, u := relativeSpan(from, to)
+	return fmt.Sprintf(template, l.unit(u).format(n))
+}
+
+// addMonthsClamped adds `n` calendar months to `v`, keeping its clock and location. When the day of
+// `v` does not exist in the target month, it is clamped to the last day of that month
+// (e.g., January 31st + 1 month => February 28th or 29th), unlike time.AddDate which overflows.
+func addMonthsClamped(v time.Time, n int) time.Time {
+	y, m, d := v.Date()
+	target := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, v.Location())
+	if last := DaysInMonthN(target); d > last {
+		d = last
+	}
+	hour, min, sec := v.Clock()
+	return time.Date(target.Year(), target.Month(), d, hour, min, sec, v.Nanosecond(), v.Location())
+}
+
+// isZeroWallClock reports whether `v` is the zero time.Time, or reads January 1, year 1, 00:00:00 on the
+// wall clock of its own location. The latter covers year-1 values parsed in a non-UTC location (e.g.,
+// "0001-01-01 00:00:00 +0918 LMT" in Asia/Tokyo), for which IsZero is false.
+func isZeroWallClock(v time.Time) bool {
+	if v.IsZero() {
+		return true
+	}
+	y, m, d := v.Date()
+	hour, min, sec := v.Clock()
+	return y == 1 && m == time.January && d == 1 && hour == 0 && min == 0 && sec == 0 && v.Nanosecond() == 0
+}
+
+// exactSeconds reports whether the configuration of the Timex instance asks for relative descriptions
+// in exact seconds, treating a missing configuration as the default (false).
+func (t *Timex) exactSeconds() bool {
+	return t.Config != nil && t.ExactSeconds
+}
+
+// inclusiveEnd returns the end-of-period value `v` as configured for the Timex instance: unchanged (the last
+// nanosecond, e.g., 23:59:59.999999999) by default, or truncated to the whole second (23:59:59) when the
+// configuration disables inclusive ends (see WithInclusiveEnd).
+func (t *Timex) inclusiveEnd(v time.Time) time.Time {
+	if t.Config != nil && t.TruncateEnd {
+		return v.Add(-time.Duration(v.Nanosecond()))
+	}
+	return v
+}
+
+// calendarSettings returns the layouts and weekday span used by CalendarLabel, falling back to the
+// defaults ("15:04", "2006-01-02" and 6 days) for unset values or a missing configuration.
+func (t *Timex) calendarSettings() (timeLayout, dateLayout string, span int) {
+	timeLayout, dateLayout, span = "15:04", "2006-01-02", 6
+	if t.Config == nil {
+		return timeLayout, dateLayout, span
+	}
+	if t.CalendarTimeLayout != "" {
+		timeLayout = t.CalendarTimeLayout
+	}
+	if t.CalendarDateLayout != "" {
+		dateLayout = t.CalendarDateLayout
+	}
+	if t.CalendarWeekdaySpan > 0 {
+		span = t.CalendarWeekdaySpan
+	}
+	return timeLayout, dateLayout, span
+}
+
+// periodStep returns the start of the period following the one beginning at `begin`, or false when
+// the period is not recognized. Calendar periods are advanced with time.AddDate, so their length
+// follows the calendar (and DST transitions) rather than a fixed duration.
+func periodStep(begin time.Time, period Period) (time.Time, bool) {
+	switch period {
+	case PeriodMinute:
+		return begin.Add(time.Minute), true
+	case PeriodHour:
+		return begin.Add(time.Hour), true
+	case PeriodDay:
+		return begin.AddDate(0, 0, 1), true
+	case PeriodWeek:
+		return begin.AddDate(0, 0, 7), true
+	case PeriodMonth:
+		return begin.AddDate(0, 1, 0), true
+	case PeriodQuarter:
+		return begin.AddDate(0, 3, 0), true
+	case PeriodHalf:
+		return begin.AddDate(0, 6, 0), true
+	case PeriodYear:
+		return begin.AddDate(1, 0, 0), true
+	default:
+		return begin, false
+	}
+}
+
+// normalizeSeparators rewrites a loosely formatted date-time string for ParseFlexible: commas become spaces,
+// whitespace runs collapse into one space, and the '.' and '/' separators of a leading year-first date become '-'.
+func normalizeSeparators(s string) string {
+	s = strings.Join(strings.Fields(strings.ReplaceAll(s, ",", " ")), " ")
+	if len(s) < 4 {
+		return s
+	}
+	if _, err := strconv.Atoi(s[:4]); err != nil {
+		return s
+	}
+	b := []byte(s)
+	for i, c := range b {
+		if c == '.' || c == '/' {
+			b[i] = '-'
+		} else if (c < '0' || c > '9') && c != '-' {
+			break
+		}
+	}
+	return string(b)
+}
+
+// configFields has the fields and JSON tags of Config, without its JSON methods, so that configJSON can
+// embed it without recursing into Config.MarshalJSON and Config.UnmarshalJSON.
+type configFields Config
+
+// configJSON is the JSON form of Config: the fields below shadow those of the embedded configFields, so the
+// location is encoded as its IANA name, the first day of the week as its name, and the name arrays are
+// omitted when unset.
+type configJSON struct {
+	*configFields
+	WeekStartDay json.RawMessage `json:"week_start_day,omitempty"`
+	TimeLocation string          `json:"time_location,omitempty"`
+	WeekdayNames []string        `json:"weekday_names,omitempty"`
+	MonthNames   []string        `json:"month_names,omitempty"`
+}
+
+// locations caches the *time.Location values loaded by loadLocation, keyed by timezone name.
+var locations sync.Map
+
+// loadLocation returns the location with the given timezone name, like time.LoadLocation, but reuses
+// the locations loaded earlier instead of reading the zoneinfo database on every call. Only successful
+// loads are cached: an invalid name is looked up again on each call and yields the same error as
+// time.LoadLocation.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
@@ -0,0 +1,397 @@
+package timefy
+
+import (
+	"sort"
+	"time"
+)
+
+// FindOverlaps returns the index pairs of all ranges in the provided slice that overlap each other.
+//
+// Two ranges overlap when each one starts strictly before the other one ends, so back-to-back ranges
+// (one's End equal to the other's Start) are not reported. The function sorts the indexes by start time
+// and sweeps through them while keeping track of the ranges that are still open, which runs in
+// O(n log n) time plus the number of reported pairs.
+//
+// Parameters:
+//
+//   - `ranges`: A slice of Range values to inspect; the input slice is not modified.
+//
+// Returns:
+//
+//   - A slice of [2]int index pairs into `ranges`, where the first index is always lower than the second.
+//     The pairs are sorted by their first index, then by their second index.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	ranges := []Range{
+//		{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)},
+//		{Start: day.Add(10 * time.Hour), End: day.Add(11 * time.Hour)},
+//		{Start: day.Add(10*time.Hour + 30*time.Minute), End: day.Add(12 * time.Hour)},
+//	}
+//	pairs := FindOverlaps(ranges) // This will return [[1 2]].
+func FindOverlaps(ranges []Range) [][2]int {
+	order := make([]int, len(ranges))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return ranges[order[a]].Start.Before(ranges[order[b]].Start)
+	})
+	var pairs [][2]int
+	var active []int
+	for _, idx := range order {
+		current := ranges[idx]
+		open := active[:0]
+		for _, a := range active {
+			if ranges[a].End.After(current.Start) {
+				open = append(open, a)
+			}
+		}
+		active = open
+		for _, a := range active {
+			if current.End.After(ranges[a].Start) {
+				if a < idx {
+					pairs = append(pairs, [2]int{a, idx})
+				} else {
+					pairs = append(pairs, [2]int{idx, a})
+				}
+			}
+		}
+		active = append(active, idx)
+	}
+	sort.Slice(pairs, func(a, b int) bool {
+		if pairs[a][0] != pairs[b][0] {
+			return pairs[a][0] < pairs[b][0]
+		}
+		return pairs[a][1] < pairs[b][1]
+	})
+	return pairs
+}
+
+// MergeRanges coalesces overlapping and touching ranges into the minimal set of covering ranges.
+//
+// The function copies and sorts the ranges by start time, then extends the current range as long as the
+// next one starts before or exactly at its end. Unlike FindOverlaps, back-to-back ranges are merged, since
+// together they cover a continuous interval. This is useful to compute total coverage or free/busy views.
+//
+// Parameters:
+//
+//   - `ranges`: A slice of Range values to merge; the input slice is not modified.
+//
+// Returns:
+//
+//   - A new slice of disjoint Range values sorted by start time; nil if `ranges` is empty.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	merged := MergeRanges([]Range{
+//		{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)},
+//		{Start: day.Add(10 * time.Hour), End: day.Add(11 * time.Hour)},
+//		{Start: day.Add(14 * time.Hour), End: day.Add(15 * time.Hour)},
+//	}) // This will return [09:00-11:00, 14:00-15:00].
+func MergeRanges(ranges []Range) []Range {
+	if len(ranges) == 0 {
+		return nil
+	}
+	sorted := make([]Range, len(ranges))
+	copy(sorted, ranges)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].Start.Before(sorted[b].Start)
+	})
+	merged := []Range{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if r.Start.After(last.End) {
+			merged = append(merged, r)
+			continue
+		}
+		if r.End.After(last.End) {
+			last.End = r.End
+		}
+	}
+	return merged
+}
+
+// FreeSlots returns the open intervals within `window` that are not covered by any busy range
+// and that last at least `minDuration`.
+//
+// The function merges the busy ranges with MergeRanges, then walks through them in order and collects
+// the gaps between the window start, the busy blocks and the window end. Busy ranges extending outside
+// the window are clipped to it, and gaps shorter than `minDuration` are discarded.
+//
+// Parameters:
+//
+//   - `window`: A Range value representing the interval to search for free time.
+//
+//   - `busy`: A slice of Range values representing the occupied intervals.
+//
+//   - `minDuration`: A time.Duration value representing the minimal length of a returned slot.
+//
+// Returns:
+//
+//   - A slice of Range values representing the free slots, sorted by start time.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	window := Range{Start: day.Add(9 * time.Hour), End: day.Add(17 * time.Hour)}
+//	busy := []Range{{Start: day.Add(10 * time.Hour), End: day.Add(12 * time.Hour)}}
+//	slots := FreeSlots(window, busy, 30*time.Minute) // This will return [09:00-10:00, 12:00-17:00].
+func FreeSlots(window Range, busy []Range, minDuration time.Duration) []Range {
+	var slots []Range
+	cursor := window.Start
+	for _, b := range MergeRanges(busy) {
+		if !b.End.After(cursor) {
+			continue
+		}
+		if !b.Start.Before(window.End) {
+			break
+		}
+		if b.Start.After(cursor) && b.Start.Sub(cursor) >= minDuration {
+			slots = append(slots, Range{Start: cursor, End: b.Start})
+		}
+		cursor = b.End
+	}
+	if window.End.After(cursor) && window.End.Sub(cursor) >= minDuration {
+		slots = append(slots, Range{Start: cursor, End: window.End})
+	}
+	return slots
+}
+
+// SnapRangeToDays expands the provided range to whole days in the given location.
+//
+// The start is moved back to the beginning of its day and the end is moved forward to the end of its day
+// (23:59:59.999999999), as computed by the BeginningOfDay() and EndOfDay() methods after converting both
+// bounds to `loc`. If `loc` is nil, each bound keeps its own location.
+//
+// Parameters:
+//
+//   - `r`: A Range value representing the interval to expand.
+//
+//   - `loc`: A pointer to a time.Location in which the day boundaries are evaluated.
+//
+// Returns:
+//
+//   - A Range value covering every day touched by `r`, from 00:00:00 to 23:59:59.999999999.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	r := Range{Start: day.Add(10 * time.Hour), End: day.Add(14 * time.Hour)}
+//	snapped := SnapRangeToDays(r, time.UTC) // This will return 2023-10-25 00:00:00 - 23:59:59.999999999.
+func SnapRangeToDays(r Range, loc *time.Location) Range {
+	start, end := r.Start, r.End
+	if loc != nil {
+		start, end = start.In(loc), end.In(loc)
+	}
+	return Range{Start: With(start).BeginningOfDay(), End: With(end).EndOfDay()}
+}
+
+// Duration returns the length of the range, i.e., End - Start.
+//
+// The result is negative when the range is inverted (End before Start) and zero for an empty range.
+//
+// Returns:
+//
+//   - A time.Duration value representing the length of the range.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	r := Range{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)}
+//	d := r.Duration() // This will return 1h.
+func (r Range) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Midpoint returns the instant halfway between the start and the end of the range.
+//
+// The midpoint is computed as Start + Duration()/2, so it keeps the location of Start. For an empty
+// range, the midpoint is Start itself.
+//
+// Returns:
+//
+//   - A time.Time value representing the middle of the range.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	r := Range{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)}
+//	mid := r.Midpoint() // This will return 2023-10-25 09:30:00 UTC.
+func (r Range) Midpoint() time.Time {
+	return r.Start.Add(r.Duration() / 2)
+}
+
+// ClampTo returns the portion of the range that lies inside `bounds`, e.g., to restrict a booking to
+// business hours.
+//
+// The clamped range starts at the later of the two starts and ends at the earlier of the two ends.
+// Consistent with FindOverlaps, ranges that merely touch (one's End equal to the other's Start) do not
+// intersect, so the boolean result is false whenever the clamped range would be empty.
+//
+// Parameters:
+//
+//   - `bounds`: The Range value representing the bounding window.
+//
+// Returns:
+//
+//   - A Range value representing the part of the range inside `bounds`, or the zero Range if there is none.
+//
+//   - A boolean value indicating whether the range and `bounds` intersect.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	booking := Range{Start: day.Add(7 * time.Hour), End: day.Add(10 * time.Hour)}
+//	hours := Range{Start: day.Add(9 * time.Hour), End: day.Add(17 * time.Hour)}
+//	clamped, ok := booking.ClampTo(hours) // This will return 09:00 - 10:00, true.
+func (r Range) ClampTo(bounds Range) (Range, bool) {
+	start, end := r.Start, r.End
+	if bounds.Start.After(start) {
+		start = bounds.Start
+	}
+	if bounds.End.Before(end) {
+		end = bounds.End
+	}
+	if !start.Before(end) {
+		return Range{}, false
+	}
+	return Range{Start: start, End: end}, true
+}
+
+// NewTimeRange creates a TimeRange from the two provided bounds, normalizing them so that Start is never
+// after End: when `start` is after `end`, the two values are swapped.
+//
+// Parameters:
+//
+//   - `start`: A time.Time value representing one bound of the range.
+//
+//   - `end`: A time.Time value representing the other bound of the range.
+//
+// Returns:
+//
+//   - A TimeRange value whose Start is not after its End.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	r := NewTimeRange(day.Add(10*time.Hour), day.Add(9*time.Hour)) // This will return 09:00 - 10:00.
+func NewTimeRange(start, end time.Time) TimeRange {
+	if start.After(end) {
+		start, end = end, start
+	}
+	return TimeRange{Start: start, End: end}
+}
+
+// Contains checks if the provided time value `v` falls within the range.
+//
+// The range is treated as half-open, [Start, End): the start is included and the end is excluded,
+// so back-to-back ranges never both contain the instant where they meet.
+//
+// Parameters:
+//
+//   - `v`: The time.Time value to check.
+//
+// Returns:
+//
+//   - A boolean value indicating whether `v` is in [Start, End).
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	r := Range{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)}
+//	ok := r.Contains(day.Add(9 * time.Hour)) // This will return true.
+//	ok = r.Contains(day.Add(10 * time.Hour)) // This will return false.
+func (r Range) Contains(v time.Time) bool {
+	return !v.Before(r.Start) && v.Before(r.End)
+}
+
+// Overlaps checks if the range and the `other` range share some time.
+//
+// Two ranges overlap when each one starts strictly before the other one ends. Ranges with touching
+// endpoints (one's End equal to the other's Start) are therefore not overlapping, which is consistent
+// with FindOverlaps and allows back-to-back bookings.
+//
+// Parameters:
+//
+//   - `other`: The Range value to compare with.
+//
+// Returns:
+//
+//   - A boolean value indicating whether the two ranges overlap.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	a := Range{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)}
+//	b := Range{Start: day.Add(10 * time.Hour), End: day.Add(11 * time.Hour)}
+//	ok := a.Overlaps(b) // This will return false, since the ranges only touch.
+func (r Range) Overlaps(other Range) bool {
+	return r.Start.Before(other.End) && other.Start.Before(r.End)
+}
+
+// Intersection returns the time shared by the range and the `other` range.
+//
+// It is equivalent to ClampTo(other): when the ranges do not overlap (see Overlaps), the zero Range
+// and false are returned.
+//
+// Parameters:
+//
+//   - `other`: The Range value to intersect with.
+//
+// Returns:
+//
+//   - A Range value representing the common part of both ranges.
+//
+//   - A boolean value indicating whether the ranges overlap.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	a := Range{Start: day.Add(9 * time.Hour), End: day.Add(11 * time.Hour)}
+//	b := Range{Start: day.Add(10 * time.Hour), End: day.Add(12 * time.Hour)}
+//	common, ok := a.Intersection(b) // This will return 10:00 - 11:00, true.
+func (r Range) Intersection(other Range) (Range, bool) {
+	return r.ClampTo(other)
+}
+
+// SplitByDay splits the range at day boundaries, returning sub-ranges that are each confined to a single
+// calendar day in the location of Start (End is converted to that location).
+//
+// Each sub-range ends where the next one starts, at the first instant of the following day. The first and
+// last sub-ranges may be partial days, and a range lying within one day yields a single element. Day
+// boundaries are computed on the calendar, so days shortened or lengthened by a DST transition produce
+// sub-ranges of 23 or 25 hours. An empty range yields a single empty element; an inverted range yields nil.
+//
+// Returns:
+//
+//   - A slice of TimeRange values covering the range, in chronological order.
+//
+// Example:
+//
+//	day := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	r := TimeRange{Start: day.Add(20 * time.Hour), End: day.Add(30 * time.Hour)}
+//	parts := r.SplitByDay() // This will return [Oct 25 20:00 - Oct 26 00:00, Oct 26 00:00 - Oct 26 06:00].
+func (r Range) SplitByDay() []TimeRange {
+	if r.End.Before(r.Start) {
+		return nil
+	}
+	loc := r.Start.Location()
+	start, end := r.Start, r.End.In(loc)
+	if start.Equal(end) {
+		return []TimeRange{{Start: start, End: end}}
+	}
+	var parts []TimeRange
+	for start.Before(end) {
+		y, m, d := start.Date()
+		next := startOfDay(y, m, d+1, loc)
+		if next.After(end) {
+			next = end
+		}
+		parts = append(parts, TimeRange{Start: start, End: next})
+		start = next
+	}
+	return parts
+}
@@ -1,6 +1,13 @@
 package timefy
 
-import "time"
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // BeginOfDay takes a time value `v` and returns a new time.Time object
 // representing the beginning of the day for that date.
@@ -49,6 +56,29 @@ func EndOfDayN(v time.Time) time.Time {
 	return time.Date(v.Year(), v.Month(), v.Day(), 23, 59, 59, 0, v.Local().Location())
 }
 
+// EndOfDayPrecise takes a time value `v` and returns a new time.Time object representing the last nanosecond
+// of the day for that date (23:59:59.999999999), in the location of `v`.
+//
+// This is the same instant as the Timex EndOfDay method returns, whereas EndOfDayN stops at 23:59:59 with
+// zero nanoseconds. Use EndOfDayPrecise for inclusive range ends: a value at 23:59:59.5 is before the precise
+// end of its day, but after the end returned by EndOfDayN.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the date from which the end of the day is extracted.
+//
+// Returns:
+//
+//   - A time.Time value representing the end of the day (23:59:59.999999999) for the provided date.
+//
+// Example:
+//
+//	v := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)
+//	end := EndOfDayPrecise(v) // This will return 2023-10-25 23:59:59.999999999 UTC.
+func EndOfDayPrecise(v time.Time) time.Time {
+	return time.Date(v.Year(), v.Month(), v.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), v.Location())
+}
+
 // PrevBeginOfDay takes a time value `v` and an integer `day` representing the number of days to go back.
 // It returns a new time.Time object representing the beginning of the day for the date `day` days before the given date.
 //
@@ -102,10 +132,11 @@ func PrevEndOfDay(v time.Time, day int) time.Time {
 // SetTimezone takes a time value `v` and a string `tz` representing the target timezone.
 // It returns a new time.Time object with the same time as `v` but converted to the specified timezone `tz`.
 //
-// The function uses time.LoadLocation to load the location based on the timezone string `tz`.
+// The function uses time.LoadLocation to load the location based on the timezone string `tz`; loaded
+// locations are cached by name, so repeated calls do not read the zoneinfo database again.
 // It then converts the input time `v` to the specified timezone using the time.In method.
 // If an error occurs while loading the timezone (for example, if the timezone string is invalid),
-// the function returns the current time value in UTC along with the error.
+// the function returns `v` unchanged along with the error.
 //
 // Parameters:
 //
@@ -124,7 +155,10 @@ func PrevEndOfDay(v time.Time, day int) time.Time {
 //	now := time.Now()
 //	nyTime, err := SetTimezone(now, "America/New_York") // This will convert the current time to New York's timezone.
 func SetTimezone(v time.Time, tz string) (time.Time, error) {
-	loc, err := time.LoadLocation(tz)
+	loc, err := loadLocation(tz)
+	if err != nil {
+		return v, err
+	}
 	now := v.In(loc)
 	return now, err
 }
@@ -159,6 +193,98 @@ func AdjustTimezone(v time.Time, tz string) time.Time {
 	return t
 }
 
+// MustSetTimezone takes a time value `v` and a string `tz` representing the target timezone,
+// and returns `v` converted to that timezone, panicking if the timezone is invalid.
+//
+// The function internally calls SetTimezone. It is intended for timezone names coming from validated
+// configuration, where an invalid name is a programming error that should fail fast, mirroring the
+// MustParse convention.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the reference time.
+//
+//   - `tz`: A string representing the IANA timezone name (e.g., "America/New_York", "Asia/Tokyo").
+//
+// Returns:
+//
+//   - A time.Time value representing the time `v` converted to the specified timezone. The function panics
+//     with an error mentioning `tz` if the timezone cannot be loaded.
+//
+// Example:
+//
+//	now := time.Now()
+//	tokyoTime := MustSetTimezone(now, "Asia/Tokyo") // This will convert the current time to Tokyo's timezone.
+//	MustSetTimezone(now, "Mars/Olympus")           // This will panic: can't load time zone "Mars/Olympus": ...
+func MustSetTimezone(v time.Time, tz string) time.Time {
+	t, err := SetTimezone(v, tz)
+	if err != nil {
+		panic(fmt.Errorf("can't load time zone %q: %w", tz, err))
+	}
+	return t
+}
+
+// MustLoadLocation loads the location with the given IANA timezone name `tz`, panicking if it is invalid.
+//
+// This is convenient for preloading locations at startup (e.g., into package-level variables) from
+// names that are known to be valid.
+//
+// Parameters:
+//
+//   - `tz`: A string representing the IANA timezone name (e.g., "Europe/London").
+//
+// Returns:
+//
+//   - A pointer to the loaded time.Location. The function panics with an error mentioning `tz`
+//     if the timezone cannot be loaded.
+//
+// Example:
+//
+//	var london = MustLoadLocation("Europe/London")
+func MustLoadLocation(tz string) *time.Location {
+	loc, err := loadLocation(tz)
+	if err != nil {
+		panic(fmt.Errorf("can't load time zone %q: %w", tz, err))
+	}
+	return loc
+}
+
+// ConvertAll takes a slice of time values `times` and a string `tz` representing the target timezone, and
+// returns a new slice holding each value converted to that timezone (e.g., a table column rendered in the
+// user's zone).
+//
+// The location is loaded once for the whole slice. The input slice is not modified, and the instants are
+// unchanged: only their location differs. An empty or nil input yields an empty slice.
+//
+// Parameters:
+//
+//   - `times`: A slice of time.Time values to convert.
+//
+//   - `tz`: A string representing the IANA timezone name (e.g., "America/New_York").
+//
+// Returns:
+//
+//   - A new slice of time.Time values in the target timezone, or nil if the timezone is invalid.
+//
+//   - An error value, which will be non-nil if the timezone cannot be loaded.
+//
+// Example:
+//
+//	column := []time.Time{time.Now(), time.Now().Add(time.Hour)}
+//	local, err := ConvertAll(column, "Asia/Tokyo")  // This will return both times in Tokyo's timezone.
+//	local, err = ConvertAll(column, "Mars/Olympus") // This will return nil and an error.
+func ConvertAll(times []time.Time, tz string) ([]time.Time, error) {
+	loc, err := loadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]time.Time, len(times))
+	for i, v := range times {
+		result[i] = v.In(loc)
+	}
+	return result, nil
+}
+
 // AddSecond takes a time value `v` and an integer `second` representing the number of seconds to add (or subtract if negative).
 // It returns a new time.Time object that is adjusted by the specified number of seconds.
 //
@@ -269,6 +395,37 @@ func AddDay(v time.Time, day int) time.Time {
 	return v.Add(time.Hour * 24 * time.Duration(day))
 }
 
+// AddDayCalendar takes a time value `v` and an integer `day` representing the number of calendar days to add (or
+// subtract if negative), and returns the same wall clock time on the resulting date.
+//
+// Unlike AddDay, which adds multiples of 24 hours of elapsed time, the function uses v.AddDate(0, 0, day), so the
+// clock time is preserved across DST transitions. For example, in America/New_York, adding one day to
+// 2023-03-11 10:00 EST yields 2023-03-12 10:00 EDT, only 23 hours later, whereas AddDay yields 11:00 EDT.
+// If the resulting wall clock does not exist (it falls in a DST gap), it is normalized as by time.Date.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the initial time
+//
+//   - `day`: An integer representing the number of calendar days to add. If negative, it subtracts the days from `v`.
+//
+// Returns:
+//
+//   - A time.Time value representing the same clock time `day` calendar days away from `v`.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("America/New_York")
+//	v := time.Date(2023, time.March, 11, 10, 0, 0, 0, loc)
+//	next := AddDayCalendar(v, 1) // This will return 2023-03-12 10:00:00 EDT.
+//	next = AddDay(v, 1)          // This will return 2023-03-12 11:00:00 EDT.
+func AddDayCalendar(v time.Time, day int) time.Time {
+	if day == 0 {
+		return v
+	}
+	return v.AddDate(0, 0, day)
+}
+
 // IsWithinTolerance checks if the provided time `v` is within a one-minute tolerance window around the current time.
 //
 // The function calculates the difference between the provided time `v` and the current time using time.Sub.
@@ -294,9 +451,66 @@ func AddDay(v time.Time, day int) time.Time {
 //	checkTime := now.Add(time.Second * 30)
 //	isOnTime := IsWithinTolerance(checkTime) // This will return true since checkTime is within 1 minute of now.
 func IsWithinTolerance(v time.Time) bool {
-	target := time.Now()
-	tolerance := time.Minute
-	diff := v.Sub(target)
+	return IsWithinToleranceOf(v, time.Minute)
+}
+
+// IsWithinToleranceOf checks if the provided time `v` is within the given tolerance window around the current time.
+//
+// The function behaves like IsWithinTolerance but lets the caller choose the size of the window, which makes it
+// suitable for checks that need a few seconds of clock skew (e.g., token expiry). It delegates to IsWithinToleranceOfRef
+// using the current time of the package clock (see SetClock) as the reference.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to check.
+//
+//   - `tolerance`: A time.Duration value representing the allowed difference, before or after the current time.
+//
+// Returns:
+//
+// - A boolean value:
+//
+//   - true if `v` is within `tolerance` (before or after) the current time;
+//
+//   - false otherwise.
+//
+// Example:
+//
+//	checkTime := time.Now().Add(3 * time.Second)
+//	isOnTime := IsWithinToleranceOf(checkTime, 5*time.Second) // This will return true.
+func IsWithinToleranceOf(v time.Time, tolerance time.Duration) bool {
+	return IsWithinToleranceOfRef(v, now(), tolerance)
+}
+
+// IsWithinToleranceOfRef checks if the provided time `v` is within the given tolerance window around
+// the reference time `ref`.
+//
+// The function calculates the difference between `v` and `ref` using time.Sub and checks that it falls
+// within the range of plus or minus `tolerance`, boundaries included. Passing an explicit reference makes
+// the check deterministic, independent of the wall clock.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to check.
+//
+//   - `ref`: A time.Time value representing the reference time.
+//
+//   - `tolerance`: A time.Duration value representing the allowed difference, before or after `ref`.
+//
+// Returns:
+//
+// - A boolean value:
+//
+//   - true if `v` is within `tolerance` (before or after) `ref`;
+//
+//   - false otherwise.
+//
+// Example:
+//
+//	ref := time.Date(2023, time.March, 15, 8, 0, 0, 0, time.UTC)
+//	isOnTime := IsWithinToleranceOfRef(ref.Add(-10*time.Second), ref, 30*time.Second) // This will return true.
+func IsWithinToleranceOfRef(v time.Time, ref time.Time, tolerance time.Duration) bool {
+	diff := v.Sub(ref)
 	return diff >= -tolerance && diff <= tolerance
 }
 
@@ -345,12 +559,111 @@ func IsLeapYearN(v time.Time) bool {
 	return IsLeapYear(v.Year())
 }
 
+// DaysInMonth returns the number of days in the specified month of the specified year.
+//
+// February has 29 days when IsLeapYear reports a leap year and 28 days otherwise;
+// April, June, September and November have 30 days; every other month has 31 days.
+//
+// Parameters:
+//
+//   - `year`: An integer representing the year.
+//
+//   - `month`: A time.Month value representing the month.
+//
+// Returns:
+//
+//   - An integer representing the number of days in the month.
+//
+// Example:
+//
+//	days := DaysInMonth(2024, time.February) // This will return 29.
+//	days = DaysInMonth(2023, time.February)  // This will return 28.
+func DaysInMonth(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if IsLeapYear(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	default:
+		return 31
+	}
+}
+
+// DaysInMonthN returns the number of days in the month of the provided time value `v`.
+//
+// The function reads the year and month from `v` and calls DaysInMonth.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the date from which the year and month are extracted.
+//
+// Returns:
+//
+//   - An integer representing the number of days in the month of `v`.
+//
+// Example:
+//
+//	now := time.Now()
+//	days := DaysInMonthN(now) // This will return the number of days in the current month.
+func DaysInMonthN(v time.Time) int {
+	return DaysInMonth(v.Year(), v.Month())
+}
+
+// IsWeekend checks if the provided time value `v` falls on a Saturday or a Sunday.
+//
+// The weekday is evaluated in the location of `v`, so the same instant may be classified
+// differently depending on the time zone it is expressed in.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the date to check.
+//
+// Returns:
+//
+// - A boolean value:
+//   - true if `v` is a Saturday or a Sunday;
+//   - false otherwise.
+//
+// Example:
+//
+//	saturday := time.Date(2023, time.October, 28, 10, 0, 0, 0, time.UTC)
+//	isWeekend := IsWeekend(saturday) // This will return true.
+func IsWeekend(v time.Time) bool {
+	d := v.Weekday()
+	return d == time.Saturday || d == time.Sunday
+}
+
+// IsWeekday checks if the provided time value `v` falls on a weekday (Monday to Friday).
+//
+// The function is the complement of IsWeekend and evaluates the weekday in the location of `v`.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the date to check.
+//
+// Returns:
+//
+// - A boolean value:
+//   - true if `v` is between Monday and Friday;
+//   - false otherwise.
+//
+// Example:
+//
+//	wednesday := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)
+//	isWeekday := IsWeekday(wednesday) // This will return true.
+func IsWeekday(v time.Time) bool {
+	return !IsWeekend(v)
+}
+
 // GetWeekdaysInRange returns a slice of time.Time objects representing all weekdays (Monday to Friday)
 // between the specified start and end dates, inclusive.
 //
-// The function iterates through each date from `start` to `end`, checking if each date is a weekday.
-// It excludes Saturdays and Sundays. It also handles leap years correctly by ensuring that February 29
-// is included only in leap years. If the year is not a leap year, it checks if the day is valid for the month.
+// The function iterates through each date from `start` to `end` using time.AddDate, checking if each date
+// is a weekday with IsWeekday. It excludes Saturdays and Sundays. Since the dates are produced by calendar
+// arithmetic, February 29 only ever appears in leap years.
 //
 // Parameters:
 //
@@ -370,22 +683,100 @@ func IsLeapYearN(v time.Time) bool {
 func GetWeekdaysInRange(start time.Time, end time.Time) []time.Time {
 	var weekdays []time.Time
 	for current := start; current.Before(end) || current.Equal(end); current = current.AddDate(0, 0, 1) {
-		d := current.Weekday()
-		if d != time.Sunday && d != time.Saturday {
-			y := current.Year()
-			if IsLeapYear(y) && current.Month() == time.February && current.Day() == 29 {
-				weekdays = append(weekdays, current)
-			} else if !IsLeapYear(y) && current.Day() <= time.Date(y, time.December, 31, 0, 0, 0, 0, time.UTC).Day() {
-				weekdays = append(weekdays, current)
-			}
+		if IsWeekday(current) {
+			weekdays = append(weekdays, current)
 		}
 	}
 	return weekdays
 }
 
+// GetWorkdaysInRange returns a slice of time.Time objects representing all workdays between the
+// specified start and end dates, inclusive. A workday is a weekday (Monday to Friday) that is not a holiday.
+//
+// The function iterates through each date from `start` to `end`, excluding Saturdays, Sundays and any date
+// listed in `holidays`. Holidays are normalized to their calendar date (year, month, day) in the location of
+// `start`, so the time-of-day component of a holiday is ignored and callers may pass full timestamps.
+//
+// Parameters:
+//
+//   - `start`: A time.Time value representing the start date of the range.
+//
+//   - `end`: A time.Time value representing the end date of the range.
+//
+//   - `holidays`: A slice of time.Time values representing the public holidays to skip.
+//
+// Returns:
+//
+//   - A slice of time.Time values representing all workdays between `start` and `end`, inclusive.
+//
+// Example:
+//
+//	start := time.Date(2023, time.December, 22, 0, 0, 0, 0, time.UTC)
+//	end := time.Date(2023, time.December, 29, 0, 0, 0, 0, time.UTC)
+//	christmas := time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC)
+//	workdays := GetWorkdaysInRange(start, end, []time.Time{christmas}) // This will return Dec 22, 26, 27, 28 and 29.
+func GetWorkdaysInRange(start time.Time, end time.Time, holidays []time.Time) []time.Time {
+	var workdays []time.Time
+	skip := holidaySet(holidays, start.Location())
+	for current := start; current.Before(end) || current.Equal(end); current = current.AddDate(0, 0, 1) {
+		if IsWeekend(current) {
+			continue
+		}
+		if _, ok := skip[dateKey(current)]; ok {
+			continue
+		}
+		workdays = append(workdays, current)
+	}
+	return workdays
+}
+
+// BusinessDaysBetween returns the number of weekdays (Monday to Friday) between the specified
+// start and end dates, inclusive.
+//
+// Unlike GetWeekdaysInRange, the function does not build a slice of dates. It counts the whole
+// weeks in the range arithmetically (5 weekdays each) and only inspects the remaining days of the
+// last partial week, so the cost is constant regardless of the size of the range. Only the calendar
+// dates matter: the time-of-day components are ignored and `end` is interpreted in the location of `start`.
+//
+// Parameters:
+//
+//   - `start`: A time.Time value representing the start date of the range.
+//
+//   - `end`: A time.Time value representing the end date of the range.
+//
+// Returns:
+//
+//   - An integer representing the number of weekdays between `start` and `end`, inclusive.
+//     It returns 0 when `end` is before `start`.
+//
+// Example:
+//
+//	start := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+//	end := time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC)
+//	count := BusinessDaysBetween(start, end) // This will return 8.
+func BusinessDaysBetween(start time.Time, end time.Time) int {
+	end = end.In(start.Location())
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	if to.Before(from) {
+		return 0
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	count := (days / 7) * 5
+	first := int(from.Weekday())
+	for i := 0; i < days%7; i++ {
+		d := time.Weekday((first + i) % 7)
+		if d != time.Sunday && d != time.Saturday {
+			count++
+		}
+	}
+	return count
+}
+
 // SinceHour calculates the number of hours that have passed since the provided time value `v`.
 //
-// The function computes the time difference between the current time and `v` using time.Since().
+// The function computes the time difference between the current time (as reported by the package clock,
+// see SetClock) and `v`.
 // The resulting duration is then converted into hours using the Hours() method.
 //
 // Parameters:
@@ -401,14 +792,15 @@ func GetWeekdaysInRange(start time.Time, end time.Time) []time.Time {
 //	start := time.Date(2023, time.March, 15, 8, 0, 0, 0, time.UTC)
 //	elapsedHours := SinceHour(start) // This will return the hours passed since March 15, 2023, 8:00 AM.
 func SinceHour(v time.Time) float64 {
-	duration := time.Since(v)
+	duration := now().Sub(v)
 	hours := duration.Hours()
 	return hours
 }
 
 // SinceMinute calculates the number of minutes that have passed since the provided time value `v`.
 //
-// The function computes the time difference between the current time and `v` using time.Since().
+// The function computes the time difference between the current time (as reported by the package clock,
+// see SetClock) and `v`.
 // The resulting duration is then converted into minutes using the Minutes() method.
 //
 // Parameters:
@@ -424,14 +816,15 @@ func SinceHour(v time.Time) float64 {
 //	start := time.Date(2023, time.March, 15, 8, 0, 0, 0, time.UTC)
 //	elapsedMinutes := SinceMinute(start) // This will return the minutes passed since March 15, 2023, 8:00 AM.
 func SinceMinute(v time.Time) float64 {
-	duration := time.Since(v)
+	duration := now().Sub(v)
 	minutes := duration.Minutes()
 	return minutes
 }
 
 // SinceSecond calculates the number of seconds that have passed since the provided time value `v`.
 //
-// The function computes the time difference between the current time and `v` using time.Since().
+// The function computes the time difference between the current time (as reported by the package clock,
+// see SetClock) and `v`.
 // The resulting duration is then converted into seconds using the Seconds() method.
 //
 // Parameters:
@@ -447,11 +840,54 @@ func SinceMinute(v time.Time) float64 {
 //	start := time.Date(2023, time.March, 15, 8, 0, 0, 0, time.UTC)
 //	elapsedSeconds := SinceSecond(start) // This will return the seconds passed since March 15, 2023, 8:00 AM.
 func SinceSecond(v time.Time) float64 {
-	duration := time.Since(v)
+	duration := now().Sub(v)
 	seconds := duration.Seconds()
 	return seconds
 }
 
+// DurationSince calculates the time that has passed since the provided time value `v`.
+//
+// The function is the equivalent of time.Since(), measured against the package clock (see SetClock).
+// Unlike SinceHour, SinceMinute and SinceSecond, it keeps the result as a time.Duration, so it can be
+// compared and formatted directly.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the starting time.
+//
+// Returns:
+//
+//   - A time.Duration value representing the elapsed time; it is negative if `v` is in the future.
+//
+// Example:
+//
+//	start := time.Now().Add(-90 * time.Second)
+//	elapsed := DurationSince(start) // This will return roughly 1m30s.
+func DurationSince(v time.Time) time.Duration {
+	return now().Sub(v)
+}
+
+// DurationUntil calculates the time remaining until the provided time value `v`.
+//
+// The function is the equivalent of time.Until(), measured against the package clock (see SetClock),
+// and complements DurationSince.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the target time.
+//
+// Returns:
+//
+//   - A time.Duration value representing the remaining time; it is negative if `v` is in the past.
+//
+// Example:
+//
+//	deadline := time.Now().Add(2 * time.Hour)
+//	remaining := DurationUntil(deadline) // This will return roughly 2h0m0s.
+func DurationUntil(v time.Time) time.Duration {
+	return v.Sub(now())
+}
+
 // FormatTimex converts a given time.Time value into a slice of integers representing various time components.
 //
 // The function extracts the hour, minute, second, nanosecond, day, month, and year from the provided
@@ -495,7 +931,7 @@ func FormatTimex(t time.Time) []int {
 //
 //	beginning := BeginningOfMinute() // This will return the current time set to the start of the minute (e.g., 12:30:00).
 func BeginningOfMinute() time.Time {
-	return With(time.Now()).BeginningOfMinute()
+	return With(now()).BeginningOfMinute()
 }
 
 // BeginningOfHour returns the current time rounded down to the beginning of the current hour.
@@ -512,7 +948,7 @@ func BeginningOfMinute() time.Time {
 //
 //	beginning := BeginningOfHour() // This will return the current time set to the start of the hour (e.g., 12:00:00).
 func BeginningOfHour() time.Time {
-	return With(time.Now()).BeginningOfHour()
+	return With(now()).BeginningOfHour()
 }
 
 // BeginningOfDay returns the current time rounded down to the beginning of the current day.
@@ -529,7 +965,7 @@ func BeginningOfHour() time.Time {
 //
 //	beginning := BeginningOfDay() // This will return the current time set to the start of the day (e.g., 2023-10-25 00:00:00).
 func BeginningOfDay() time.Time {
-	return With(time.Now()).BeginningOfDay()
+	return With(now()).BeginningOfDay()
 }
 
 // BeginningOfWeek returns the current time rounded down to the beginning of the current week.
@@ -547,7 +983,7 @@ func BeginningOfDay() time.Time {
 //
 //	beginning := BeginningOfWeek() // This will return the current time set to the start of the week (e.g., 2023-10-22 00:00:00 if Sunday is the start of the week).
 func BeginningOfWeek() time.Time {
-	return With(time.Now()).BeginningOfWeek()
+	return With(now()).BeginningOfWeek()
 }
 
 // BeginningOfMonth returns the current time rounded down to the beginning of the current month.
@@ -564,7 +1000,7 @@ func BeginningOfWeek() time.Time {
 //
 //	beginning := BeginningOfMonth() // This will return the current time set to the start of the month (e.g., 2023-10-01 00:00:00).
 func BeginningOfMonth() time.Time {
-	return With(time.Now()).BeginningOfMonth()
+	return With(now()).BeginningOfMonth()
 }
 
 // BeginningOfQuarter returns the current time rounded down to the beginning of the current quarter.
@@ -589,7 +1025,7 @@ func BeginningOfMonth() time.Time {
 //
 //	beginning := BeginningOfQuarter() // This will return the current time set to the start of the current quarter (e.g., 2023-10-01 00:00:00 if it's the fourth quarter).
 func BeginningOfQuarter() time.Time {
-	return With(time.Now()).BeginningOfQuarter()
+	return With(now()).BeginningOfQuarter()
 }
 
 // BeginningOfYear returns the current time rounded down to the beginning of the current year.
@@ -606,7 +1042,7 @@ func BeginningOfQuarter() time.Time {
 //
 //	beginning := BeginningOfYear() // This will return the current time set to the start of the year (e.g., 2023-01-01 00:00:00).
 func BeginningOfYear() time.Time {
-	return With(time.Now()).BeginningOfYear()
+	return With(now()).BeginningOfYear()
 }
 
 // EndOfMinute returns the current time rounded up to the end of the current minute.
@@ -623,7 +1059,7 @@ func BeginningOfYear() time.Time {
 //
 //	end := EndOfMinute() // This will return the current time set to the end of the minute (e.g., 12:30:59.999999999).
 func EndOfMinute() time.Time {
-	return With(time.Now()).EndOfMinute()
+	return With(now()).EndOfMinute()
 }
 
 // EndOfHour returns the current time rounded up to the end of the current hour.
@@ -640,7 +1076,7 @@ func EndOfMinute() time.Time {
 //
 //	end := EndOfHour() // This will return the current time set to the end of the hour (e.g., 12:59:59.999999999).
 func EndOfHour() time.Time {
-	return With(time.Now()).EndOfHour()
+	return With(now()).EndOfHour()
 }
 
 // EndOfDay returns the current time rounded up to the end of the current day.
@@ -657,7 +1093,7 @@ func EndOfHour() time.Time {
 //
 //	end := EndOfDay() // This will return the current time set to the end of the day (e.g., 2023-10-25 23:59:59.999999999).
 func EndOfDay() time.Time {
-	return With(time.Now()).EndOfDay()
+	return With(now()).EndOfDay()
 }
 
 // EndOfWeek returns the current time rounded up to the end of the current week.
@@ -675,7 +1111,7 @@ func EndOfDay() time.Time {
 //
 //	end := EndOfWeek() // This will return the current time set to the end of the week (e.g., 2023-10-29 23:59:59.999999999).
 func EndOfWeek() time.Time {
-	return With(time.Now()).EndOfWeek()
+	return With(now()).EndOfWeek()
 }
 
 // EndOfMonth returns the current time rounded up to the end of the current month.
@@ -693,7 +1129,7 @@ func EndOfWeek() time.Time {
 //
 //	end := EndOfMonth() // This will return the current time set to the end of the month (e.g., 2023-10-31 23:59:59.999999999).
 func EndOfMonth() time.Time {
-	return With(time.Now()).EndOfMonth()
+	return With(now()).EndOfMonth()
 }
 
 // EndOfQuarter returns the current time rounded up to the end of the current quarter.
@@ -719,7 +1155,7 @@ func EndOfMonth() time.Time {
 //
 //	end := EndOfQuarter() // This will return the current time set to the end of the current quarter (e.g., 2023-12-31 23:59:59.999999999 if it's the fourth quarter).
 func EndOfQuarter() time.Time {
-	return With(time.Now()).EndOfQuarter()
+	return With(now()).EndOfQuarter()
 }
 
 // EndOfYear returns the current time rounded up to the end of the current year.
@@ -737,7 +1173,7 @@ func EndOfQuarter() time.Time {
 //
 //	end := EndOfYear() // This will return the current time set to the end of the current year (e.g., 2023-12-31 23:59:59.999999999).
 func EndOfYear() time.Time {
-	return With(time.Now()).EndOfYear()
+	return With(now()).EndOfYear()
 }
 
 // Monday returns the date and time of the most recent or upcoming Monday relative to the current time.
@@ -756,7 +1192,7 @@ func EndOfYear() time.Time {
 //	monday := Monday() // This will return the date and time for the next upcoming Monday (e.g., 2023-10-30 00:00:00).
 //	mondayFormatted := Monday("2006-01-02") // This will return the next Monday formatted as "YYYY-MM-DD".
 func Monday(s ...string) time.Time {
-	return With(time.Now()).Monday(s...)
+	return With(now()).Monday(s...)
 }
 
 // Sunday returns the date and time of the most recent or upcoming Sunday relative to the current time.
@@ -775,7 +1211,7 @@ func Monday(s ...string) time.Time {
 //	sunday := Sunday() // This will return the date and time for the next upcoming Sunday (e.g., 2023-10-29 00:00:00).
 //	sundayFormatted := Sunday("2006-01-02") // This will return the next Sunday formatted as "YYYY-MM-DD".
 func Sunday(s ...string) time.Time {
-	return With(time.Now()).Sunday(s...)
+	return With(now()).Sunday(s...)
 }
 
 // EndOfSunday returns the date and time representing the end of the most recent or upcoming Sunday
@@ -793,7 +1229,7 @@ func Sunday(s ...string) time.Time {
 //
 //	end := EndOfSunday() // This will return the date and time set to the end of the next Sunday (e.g., 2023-10-29 23:59:59.999999999).
 func EndOfSunday() time.Time {
-	return With(time.Now()).EndOfSunday()
+	return With(now()).EndOfSunday()
 }
 
 // Quarter returns the current quarter of the year based on the current date and time.
@@ -813,19 +1249,60 @@ func EndOfSunday() time.Time {
 //
 //	quarter := Quarter() // This will return the current quarter (e.g., 4 for October).
 func Quarter() uint {
-	return With(time.Now()).Quarter()
+	return With(now()).Quarter()
 }
 
-// Parse takes a variable number of string inputs and attempts to parse them into a time.Time value.
-// This function uses the With() function to obtain the current time as a reference point and then
-// applies the Parse() method to interpret the provided string(s) as time.
+// QuarterOf returns the quarter of the year of the provided time value `v`, in the location of `v`.
+// Unlike Quarter, which reads the current time, it works for any date, e.g., historical ones:
+//   - Q1: January to March
+//   - Q2: April to June
+//   - Q3: July to September
+//   - Q4: October to December
 //
-// The function can handle multiple formats and will return the first successfully parsed time value
-// along with any potential error encountered during parsing.
+// Parameters:
+//
+//   - `v`: A time.Time value representing the date.
 //
 // Returns:
-//   - A time.Time value representing the parsed time if successful.
-//   - An error indicating any issues encountered during the parsing process, or nil if parsing was successful.
+//
+//   - A uint value representing the quarter of the year (1, 2, 3, or 4).
+//
+// Example:
+//
+//	quarter := QuarterOf(time.Date(2019, time.May, 10, 0, 0, 0, 0, time.UTC)) // This will return 2.
+func QuarterOf(v time.Time) uint {
+	return (uint(v.Month())-1)/3 + 1
+}
+
+// HalfOf returns the half of the year of the provided time value `v`, in the location of `v`:
+// 1 for January to June (H1) and 2 for July to December (H2).
+// It complements BeginningOfHalf and EndOfHalf, and works for any date.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the date.
+//
+// Returns:
+//
+//   - A uint value representing the half of the year (1 or 2).
+//
+// Example:
+//
+//	half := HalfOf(time.Date(2019, time.September, 10, 0, 0, 0, 0, time.UTC)) // This will return 2.
+func HalfOf(v time.Time) uint {
+	return (uint(v.Month())-1)/6 + 1
+}
+
+// Parse takes a variable number of string inputs and attempts to parse them into a time.Time value.
+// This function uses the With() function to obtain the current time as a reference point and then
+// applies the Parse() method to interpret the provided string(s) as time.
+//
+// The function can handle multiple formats and will return the first successfully parsed time value
+// along with any potential error encountered during parsing.
+//
+// Returns:
+//   - A time.Time value representing the parsed time if successful.
+//   - An error indicating any issues encountered during the parsing process, or nil if parsing was successful.
 //
 // Example:
 //
@@ -834,7 +1311,7 @@ func Quarter() uint {
 //		// Handle the parsing error
 //	}
 func Parse(s ...string) (time.Time, error) {
-	return With(time.Now()).Parse(s...)
+	return With(now()).Parse(s...)
 }
 
 // ParseInLocation takes a variable number of string inputs and attempts to parse them into a time.Time value
@@ -860,7 +1337,7 @@ func Parse(s ...string) (time.Time, error) {
 //		// Handle the parsing error
 //	}
 func ParseInLocation(loc *time.Location, s ...string) (time.Time, error) {
-	return With(time.Now().In(loc)).Parse(s...)
+	return With(now().In(loc)).Parse(s...)
 }
 
 // MustParse takes a variable number of string inputs and attempts to parse them into a time.Time value.
@@ -883,7 +1360,7 @@ func ParseInLocation(loc *time.Location, s ...string) (time.Time, error) {
 //	timeValue := MustParse("2023-10-25") // This will return the parsed time if the input string is in a valid format.
 //	// If the input is invalid, it will cause a panic.
 func MustParse(s ...string) time.Time {
-	return With(time.Now()).MustParse(s...)
+	return With(now()).MustParse(s...)
 }
 
 // MustParseInLocation takes a variable number of string inputs and attempts to parse them into a time.Time value
@@ -908,7 +1385,7 @@ func MustParse(s ...string) time.Time {
 //	timeValue := MustParseInLocation(time.UTC, "2023-10-25") // This will return the parsed time in UTC if the input string is in a valid format.
 //	// If the input is invalid, it will cause a panic.
 func MustParseInLocation(loc *time.Location, s ...string) time.Time {
-	return With(time.Now().In(loc)).MustParse(s...)
+	return With(now().In(loc)).MustParse(s...)
 }
 
 // Between takes two string inputs representing time values and checks if the current time falls
@@ -930,5 +1407,2053 @@ func MustParseInLocation(loc *time.Location, s ...string) time.Time {
 //	isWithin := Between("2023-10-20", "2023-10-30") // This will return true if the current time is between these two dates.
 //	isWithin := Between("2023-10-25", "2023-10-26") // This will return true if the current date is exactly 2023-10-25.
 func Between(time1, time2 string) bool {
-	return With(time.Now()).Between(time1, time2)
+	return With(now()).Between(time1, time2)
+}
+
+// PeriodStartUnix returns the Unix timestamp (in seconds) of the beginning of the period
+// containing the provided time value `v`, evaluated in the given location.
+//
+// The function converts `v` into `loc` (or keeps the location of `v` when `loc` is nil), wraps it
+// with the With() function and applies the BeginningOf() method. Since every time value within the
+// same period maps to the same start instant, the result is a stable integer bucket identifier,
+// which is suitable for cache keys and rate-limiting windows.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to bucket.
+//
+//   - `period`: A Period value (e.g., PeriodHour, PeriodDay) identifying the bucket size.
+//
+//   - `loc`: A pointer to a time.Location used to determine the period boundaries; nil keeps the location of `v`.
+//
+// Returns:
+//
+//   - An int64 value representing the Unix seconds of the start of the period.
+//
+// Example:
+//
+//	now := time.Now()
+//	bucket := PeriodStartUnix(now, PeriodHour, time.UTC) // This will return the Unix seconds of the current UTC hour start.
+func PeriodStartUnix(v time.Time, period Period, loc *time.Location) int64 {
+	if loc != nil {
+		v = v.In(loc)
+	}
+	return With(v).BeginningOf(period).Unix()
+}
+
+// WeekElapsedFraction returns how far the provided time value `v` has progressed through its week,
+// using `weekStart` as the first day of the week.
+//
+// The function computes the beginning and the end of the week containing `v` (in the location of `v`)
+// with a Config carrying the given week start day, and divides the time elapsed since the beginning
+// of the week by the length of the week. The length is measured between the actual boundaries,
+// so weeks shortened or lengthened by a DST transition are handled correctly.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to evaluate.
+//
+//   - `weekStart`: A time.Weekday value representing the first day of the week (e.g., time.Sunday, time.Monday).
+//
+// Returns:
+//
+//   - A float64 value in the range [0, 1): 0 at the beginning of the week, approaching 1 at the end of the week.
+//
+// Example:
+//
+//	v := time.Date(2024, time.October, 23, 12, 0, 0, 0, time.UTC) // Wednesday noon
+//	fraction := WeekElapsedFraction(v, time.Sunday) // This will return 0.5.
+func WeekElapsedFraction(v time.Time, weekStart time.Weekday) float64 {
+	t := (&Config{WeekStartDay: weekStart}).With(v)
+	begin := t.BeginningOfWeek()
+	length := begin.AddDate(0, 0, 7).Sub(begin)
+	return float64(v.Sub(begin)) / float64(length)
+}
+
+// DayOfYearToDate returns the date corresponding to the given 1-based day of the year,
+// which is the inverse of time.Time.YearDay().
+//
+// The function validates `dayOfYear` against the number of days of `year` (366 for leap years as
+// determined by IsLeapYear, 365 otherwise) and returns the start of that day in the location `loc`: midnight,
+// or the end of the DST transition in zones where one skips midnight, so that the result always falls on the
+// requested day. If `loc` is nil, the local time zone is used.
+//
+// Parameters:
+//
+//   - `year`: An integer representing the year.
+//
+//   - `dayOfYear`: An integer representing the 1-based day of the year (1 to 365, or 366 in leap years).
+//
+//   - `loc`: A pointer to a time.Location in which the date is built.
+//
+// Returns:
+//
+//   - A time.Time value representing the start of the requested day.
+//
+//   - An error value, which will be non-nil if `dayOfYear` is out of range for `year`.
+//
+// Example:
+//
+//	date, err := DayOfYearToDate(2024, 60, time.UTC) // This will return 2024-02-29 00:00:00 UTC.
+//	date, err = DayOfYearToDate(2023, 60, time.UTC)  // This will return 2023-03-01 00:00:00 UTC.
+func DayOfYearToDate(year, dayOfYear int, loc *time.Location) (time.Time, error) {
+	days := 365
+	if IsLeapYear(year) {
+		days = 366
+	}
+	if dayOfYear < 1 || dayOfYear > days {
+		return time.Time{}, fmt.Errorf("day of year out of range: %d (year %d has %d days)", dayOfYear, year, days)
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return startOfDay(year, time.January, dayOfYear, loc), nil
+}
+
+// DayOfYear returns the 1-based ordinal day of the year of the provided time value `v`, in the location of `v`.
+//
+// The result ranges from 1 (January 1st) to 365, or 366 on December 31st of a leap year. It is the same as
+// v.YearDay() and the inverse of DayOfYearToDate.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the date.
+//
+// Returns:
+//
+//   - An integer representing the day of the year (1 to 366).
+//
+// Example:
+//
+//	day := DayOfYear(time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)) // This will return 366.
+//	day = DayOfYear(time.Date(2023, time.October, 7, 0, 0, 0, 0, time.UTC))    // This will return 280.
+func DayOfYear(v time.Time) int {
+	return v.YearDay()
+}
+
+// DaysRemainingInYear returns the number of days left in the year of the provided time value `v` after the
+// day of `v`, honoring leap years (e.g., for a "day 280 of 365" progress bar).
+//
+// The day of `v` itself is not counted, so December 31st returns 0 and January 1st returns 364, or 365 in a
+// leap year. DayOfYear(v) + DaysRemainingInYear(v) is always the number of days of the year.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the date.
+//
+// Returns:
+//
+//   - An integer representing the days remaining in the year (0 to 365).
+//
+// Example:
+//
+//	days := DaysRemainingInYear(time.Date(2023, time.October, 7, 0, 0, 0, 0, time.UTC)) // This will return 85.
+//	days = DaysRemainingInYear(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))  // This will return 365.
+func DaysRemainingInYear(v time.Time) int {
+	days := 365
+	if IsLeapYear(v.Year()) {
+		days = 366
+	}
+	return days - v.YearDay()
+}
+
+// MondayOf returns the start of the Monday within the week containing the provided time value `v`.
+//
+// MondayOf and its siblings TuesdayOf to SundayOf consider weeks to run from Monday to Sunday, as the
+// Monday() and Sunday() helpers do, and do not depend on the global `WeekStartDay`, which keeps them
+// deterministic. If `v` already falls on the requested day, the start of that day is returned. The result
+// is in the location of `v`, at midnight, or at the end of the DST transition in zones where one skips
+// midnight (e.g., America/Santiago), so it always falls on the requested day.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing any moment of the week.
+//
+// Returns:
+//
+//   - A time.Time value representing the start of Monday of the same week.
+//
+// Example:
+//
+//	v := time.Date(2024, time.October, 23, 15, 0, 0, 0, time.UTC) // Wednesday
+//	monday := MondayOf(v) // This will return 2024-10-21 00:00:00 UTC.
+func MondayOf(v time.Time) time.Time {
+	return weekdayOf(v, time.Monday)
+}
+
+// TuesdayOf returns the start of the Tuesday within the Monday-based week containing `v` (see MondayOf).
+func TuesdayOf(v time.Time) time.Time {
+	return weekdayOf(v, time.Tuesday)
+}
+
+// WednesdayOf returns the start of the Wednesday within the Monday-based week containing `v` (see MondayOf).
+func WednesdayOf(v time.Time) time.Time {
+	return weekdayOf(v, time.Wednesday)
+}
+
+// ThursdayOf returns the start of the Thursday within the Monday-based week containing `v` (see MondayOf).
+func ThursdayOf(v time.Time) time.Time {
+	return weekdayOf(v, time.Thursday)
+}
+
+// FridayOf returns the start of the Friday within the Monday-based week containing `v` (see MondayOf).
+func FridayOf(v time.Time) time.Time {
+	return weekdayOf(v, time.Friday)
+}
+
+// SaturdayOf returns the start of the Saturday within the Monday-based week containing `v` (see MondayOf).
+func SaturdayOf(v time.Time) time.Time {
+	return weekdayOf(v, time.Saturday)
+}
+
+// SundayOf returns the start of the Sunday within the Monday-based week containing `v` (see MondayOf).
+func SundayOf(v time.Time) time.Time {
+	return weekdayOf(v, time.Sunday)
+}
+
+// TimeAtOffsetIntoDay returns the instant located `offset` after the beginning of the day of `date`,
+// in the location of `date`.
+//
+// The offset is elapsed time added to midnight with time.Add, not a wall-clock reading. On regular days
+// both are the same (an offset of 9h30m yields 09:30 local), but on a DST transition day the wall clock
+// differs by the size of the shift (e.g., 9h after midnight on a spring-forward day reads 10:00).
+// Offsets larger than the length of the day roll over into the following day, and negative offsets
+// move into the previous day.
+//
+// Parameters:
+//
+//   - `date`: A time.Time value representing the day of reference; its time-of-day is ignored.
+//
+//   - `offset`: A time.Duration value representing the elapsed time since midnight.
+//
+// Returns:
+//
+//   - A time.Time value representing midnight of `date` plus `offset`.
+//
+// Example:
+//
+//	date := time.Date(2023, time.October, 25, 18, 0, 0, 0, time.UTC)
+//	v := TimeAtOffsetIntoDay(date, 9*time.Hour+30*time.Minute) // This will return 2023-10-25 09:30:00 UTC.
+func TimeAtOffsetIntoDay(date time.Time, offset time.Duration) time.Time {
+	return With(date).BeginningOfDay().Add(offset)
+}
+
+// ISOWeek returns the ISO 8601 year and week number of the provided time value `v`.
+//
+// It utilizes the With() function to wrap `v` and then applies the WeekOfYear() method. Early January
+// dates may belong to the last week of the previous ISO year, and late December dates may belong to
+// week 1 of the next ISO year.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the date to evaluate.
+//
+// Returns:
+//
+//   - An integer representing the ISO year.
+//
+//   - An integer between 1 and 53 representing the ISO week number.
+//
+// Example:
+//
+//	v := time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC)
+//	year, week := ISOWeek(v) // This will return 2019, 1.
+func ISOWeek(v time.Time) (int, int) {
+	return With(v).WeekOfYear()
+}
+
+// AgeBucket returns the index of the age bucket that a person born at `birth` falls into at the time `at`.
+//
+// The age is the number of completed years between `birth` and `at` (a February 29th birthday is
+// celebrated on February 28th in non-leap years). The `bounds` slice lists the ascending lower bounds of
+// each bucket after the first one, so for bounds [18, 25, 35, 50] the function returns 0 for ages under 18,
+// 1 for 18-24, 2 for 25-34, 3 for 35-49 and 4 for 50 and above. An age equal to a bound belongs to the
+// bucket starting at that bound.
+//
+// Parameters:
+//
+//   - `birth`: A time.Time value representing the date of birth.
+//
+//   - `at`: A time.Time value representing the moment at which the age is evaluated.
+//
+//   - `bounds`: A slice of ascending integers representing the lower bounds of the buckets.
+//
+// Returns:
+//
+//   - An integer between 0 and len(bounds) representing the bucket index.
+//
+// Example:
+//
+//	birth := time.Date(2000, time.June, 15, 0, 0, 0, 0, time.UTC)
+//	at := time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC)
+//	bucket := AgeBucket(birth, at, []int{18, 25, 35, 50}) // This will return 2 (age 25).
+func AgeBucket(birth, at time.Time, bounds []int) int {
+	age := completedYears(birth, at)
+	return sort.SearchInts(bounds, age+1)
+}
+
+// NextOccurrence returns the earliest instant strictly after `v` whose wall clock reads the given
+// hour, minute and second, in the location of `v`.
+//
+// If today's occurrence is still ahead of `v`, it is returned; otherwise the function rolls over to the
+// next day. On DST transition days, a wall clock skipped by a spring-forward transition resolves to the
+// same elapsed time after midnight (e.g., 02:30 becomes 03:30), and a wall clock repeated by a fall-back
+// transition resolves to the earlier of the two instants.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the reference time.
+//
+//   - `hour`: An integer between 0 and 23 representing the target hour.
+//
+//   - `min`: An integer between 0 and 59 representing the target minute.
+//
+//   - `sec`: An integer between 0 and 59 representing the target second.
+//
+// Returns:
+//
+//   - A time.Time value representing the next occurrence of the clock time after `v`.
+//
+// Example:
+//
+//	v := time.Date(2023, time.October, 25, 15, 0, 0, 0, time.UTC)
+//	next := NextOccurrence(v, 14, 30, 0) // This will return 2023-10-26 14:30:00 UTC.
+func NextOccurrence(v time.Time, hour, min, sec int) time.Time {
+	y, m, d := v.Date()
+	next := clockOn(y, m, d, v.Location(), hour, min, sec)
+	if !next.After(v) {
+		next = clockOn(y, m, d+1, v.Location(), hour, min, sec)
+	}
+	return next
+}
+
+// SetClock replaces the source of the current time used by the package helpers
+// (e.g., BeginningOfDay(), Monday(), Parse(), IsWithinTolerance()).
+//
+// The function assigns the Now method of `c` to the package-level `NowFunc`. Passing nil restores
+// the default clock, time.Now. When the clock is never overridden, the helpers keep using the wall clock.
+//
+// Parameters:
+//
+//   - `c`: A Clock implementation providing the current time, or nil to restore time.Now.
+//
+// Example:
+//
+//	SetClock(myClock)   // Helpers now read the current time from myClock.
+//	defer SetClock(nil) // Restore the wall clock afterwards.
+func SetClock(c Clock) {
+	if c == nil {
+		NowFunc = time.Now
+		return
+	}
+	NowFunc = c.Now
+}
+
+// FreezeTime makes the package helpers report `v` as the current time until the clock is reset
+// with SetClock(nil). It is intended for deterministic tests.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the frozen current time.
+//
+// Example:
+//
+//	FreezeTime(time.Date(2023, time.October, 25, 15, 4, 5, 0, time.UTC))
+//	defer SetClock(nil)
+//	start := BeginningOfDay() // This will return 2023-10-25 00:00:00 UTC.
+func FreezeTime(v time.Time) {
+	SetClock(fixedClock(v))
+}
+
+// AddBusinessDays takes a time value `v` and an integer `days` representing the number of business days
+// to add (or subtract if negative), skipping Saturdays and Sundays.
+//
+// The function moves one calendar day at a time with time.AddDate, so the time-of-day and the location
+// of `v` are preserved (also across DST transitions), and only weekdays are counted. For example, adding
+// 1 business day to a Friday lands on the following Monday. If `days` is 0, `v` is returned unchanged.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the initial time.
+//
+//   - `days`: An integer representing the number of business days to add. If negative, it moves backwards.
+//
+// Returns:
+//
+//   - A time.Time value representing `v` shifted by the specified number of business days.
+//
+// Example:
+//
+//	friday := time.Date(2023, time.October, 27, 9, 0, 0, 0, time.UTC)
+//	next := AddBusinessDays(friday, 1) // This will return Monday 2023-10-30 09:00:00 UTC.
+func AddBusinessDays(v time.Time, days int) time.Time {
+	return AddBusinessDaysWithHolidays(v, days, nil)
+}
+
+// AddBusinessDaysWithHolidays behaves like AddBusinessDays but additionally skips the given holidays.
+//
+// Holidays are compared by calendar date (year, month, day) in the location of `v`, so their
+// time-of-day component is ignored.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the initial time.
+//
+//   - `days`: An integer representing the number of business days to add. If negative, it moves backwards.
+//
+//   - `holidays`: A slice of time.Time values representing the public holidays to skip.
+//
+// Returns:
+//
+//   - A time.Time value representing `v` shifted by the specified number of business days.
+//
+// Example:
+//
+//	friday := time.Date(2023, time.December, 22, 9, 0, 0, 0, time.UTC)
+//	christmas := time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC)
+//	next := AddBusinessDaysWithHolidays(friday, 1, []time.Time{christmas}) // This will return 2023-12-26 09:00:00 UTC.
+func AddBusinessDaysWithHolidays(v time.Time, days int, holidays []time.Time) time.Time {
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+	skip := holidaySet(holidays, v.Location())
+	for days > 0 {
+		v = v.AddDate(0, 0, step)
+		if _, ok := skip[dateKey(v)]; ok || IsWeekend(v) {
+			continue
+		}
+		days--
+	}
+	return v
+}
+
+// NextBusinessDay returns the first business day (Monday to Friday) strictly after the date of `v`, at the same
+// clock time as `v`.
+//
+// Any date is accepted, including a weekend one: from a Friday, Saturday or Sunday, the result is the
+// following Monday. It is equivalent to AddBusinessDays(v, 1), so the location and the wall clock are
+// preserved across DST transitions.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the reference date.
+//
+// Returns:
+//
+//   - A time.Time value representing the next business day.
+//
+// Example:
+//
+//	saturday := time.Date(2023, time.October, 28, 9, 0, 0, 0, time.UTC)
+//	next := NextBusinessDay(saturday) // This will return Monday 2023-10-30 09:00:00 UTC.
+func NextBusinessDay(v time.Time) time.Time {
+	return AddBusinessDays(v, 1)
+}
+
+// PreviousBusinessDay returns the last business day (Monday to Friday) strictly before the date of `v`, at the
+// same clock time as `v`.
+//
+// Any date is accepted, including a weekend one: from a Saturday, Sunday or Monday, the result is the
+// preceding Friday. It is equivalent to AddBusinessDays(v, -1).
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the reference date.
+//
+// Returns:
+//
+//   - A time.Time value representing the previous business day.
+//
+// Example:
+//
+//	saturday := time.Date(2023, time.October, 28, 9, 0, 0, 0, time.UTC)
+//	prev := PreviousBusinessDay(saturday) // This will return Friday 2023-10-27 09:00:00 UTC.
+func PreviousBusinessDay(v time.Time) time.Time {
+	return AddBusinessDays(v, -1)
+}
+
+// NextBusinessDayWithHolidays behaves like NextBusinessDay but additionally skips the given holidays,
+// compared by calendar date in the location of `v`.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the reference date.
+//
+//   - `holidays`: A slice of time.Time values representing the public holidays to skip.
+//
+// Returns:
+//
+//   - A time.Time value representing the next business day that is not a holiday.
+//
+// Example:
+//
+//	saturday := time.Date(2023, time.December, 23, 9, 0, 0, 0, time.UTC)
+//	christmas := time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC)
+//	next := NextBusinessDayWithHolidays(saturday, []time.Time{christmas}) // This will return 2023-12-26 09:00:00 UTC.
+func NextBusinessDayWithHolidays(v time.Time, holidays []time.Time) time.Time {
+	return AddBusinessDaysWithHolidays(v, 1, holidays)
+}
+
+// PreviousBusinessDayWithHolidays behaves like PreviousBusinessDay but additionally skips the given holidays,
+// compared by calendar date in the location of `v`.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the reference date.
+//
+//   - `holidays`: A slice of time.Time values representing the public holidays to skip.
+//
+// Returns:
+//
+//   - A time.Time value representing the previous business day that is not a holiday.
+//
+// Example:
+//
+//	sunday := time.Date(2023, time.December, 31, 9, 0, 0, 0, time.UTC)
+//	friday := time.Date(2023, time.December, 29, 0, 0, 0, 0, time.UTC)
+//	prev := PreviousBusinessDayWithHolidays(sunday, []time.Time{friday}) // This will return 2023-12-28 09:00:00 UTC.
+func PreviousBusinessDayWithHolidays(v time.Time, holidays []time.Time) time.Time {
+	return AddBusinessDaysWithHolidays(v, -1, holidays)
+}
+
+// BetweenTime checks if the provided time value `v` falls within the range defined by `start` and `end`,
+// boundaries included.
+//
+// It utilizes the With() function to wrap `v` and then applies the BetweenTime() method. The bounds are
+// treated as an unordered pair: if `start` is after `end`, they are swapped.
+//
+// Parameters:
+//   - v: A time.Time value representing the time to check.
+//   - start: A time.Time value representing one bound of the range.
+//   - end: A time.Time value representing the other bound of the range.
+//
+// Returns:
+//   - A boolean value indicating whether `v` is within the specified range (inclusive).
+//
+// Example:
+//
+//	start := time.Date(2023, time.October, 20, 0, 0, 0, 0, time.UTC)
+//	end := time.Date(2023, time.October, 30, 0, 0, 0, 0, time.UTC)
+//	isWithin := BetweenTime(end, start, end) // This will return true, since the boundaries are included.
+func BetweenTime(v, start, end time.Time) bool {
+	return With(v).BetweenTime(start, end)
+}
+
+// LastOccurrence returns the most recent instant, at or before `ref`, that falls on the given weekday
+// at the given clock time, in the location of `ref`.
+//
+// The function steps back from the calendar date of `ref` to the closest matching weekday. If the clock
+// time on that day is still ahead of `ref` (e.g., the target is later today), it falls back one week.
+// This is handy for building windows such as "since last Monday 9am".
+//
+// Parameters:
+//
+//   - `ref`: The reference time.Time value; the result never comes after it.
+//
+//   - `weekday`: The target day of the week.
+//
+//   - `clock`: The target time of day.
+//
+// Returns:
+//
+//   - A time.Time value representing the previous occurrence of the weekday and clock time.
+//
+// Example:
+//
+//	ref := time.Date(2023, time.October, 25, 15, 0, 0, 0, time.UTC) // Wednesday
+//	last := LastOccurrence(ref, time.Wednesday, ClockTime{Hour: 9}) // This will return 2023-10-25 09:00:00 UTC.
+//	last = LastOccurrence(ref, time.Wednesday, ClockTime{Hour: 18}) // This will return 2023-10-18 18:00:00 UTC.
+func LastOccurrence(ref time.Time, weekday time.Weekday, clock ClockTime) time.Time {
+	y, m, d := ref.Date()
+	d -= (int(ref.Weekday()) - int(weekday) + 7) % 7
+	last := clockOn(y, m, d, ref.Location(), clock.Hour, clock.Minute, clock.Second)
+	if last.After(ref) {
+		last = clockOn(y, m, d-7, ref.Location(), clock.Hour, clock.Minute, clock.Second)
+	}
+	return last
+}
+
+// BucketID returns a short, stable identifier for the time window of length `size` containing `v`.
+//
+// Windows are aligned on the Unix epoch: the identifier is the base-36 encoding of the index of the window,
+// floor(nanoseconds since 1970 / size), so negative indices (instants before 1970) are prefixed with "-". The
+// index is computed from v.Unix() and v.Nanosecond() with 128-bit intermediate values, so unlike UnixNano it
+// does not overflow outside the years 1678-2262, and no memory is allocated apart from the returned string.
+// Two instants in the same window always share an identifier and adjacent windows always differ, regardless
+// of the location of `v`. If `size` is zero or negative, each nanosecond is its own bucket.
+//
+// The size is not part of the identifier: the N-th window of an hour and the N-th window of a minute yield
+// the same string, so identifiers are only unique among buckets of one size. Callers mixing several sizes
+// in one key space should qualify the identifier with the size (e.g., "1h:" + BucketID(v, time.Hour)).
+//
+// Parameters:
+//
+//   - `v`: The time.Time value to locate.
+//
+//   - `size`: The length of each window.
+//
+// Returns:
+//
+//   - A string identifying the window containing `v`, suitable for cache keys or sharding.
+//
+// Example:
+//
+//	v := time.Date(2023, time.October, 25, 15, 4, 5, 0, time.UTC)
+//	id := BucketID(v, time.Hour) // Returns the base-36 index of the hour 2023-10-25 15:00 UTC.
+func BucketID(v time.Time, size time.Duration) string {
+	if size <= 0 {
+		size = time.Nanosecond
+	}
+	// Write the seconds as whole windows plus a remainder, sec = windows*size + rem with 0 <= rem < size,
+	// so that the index is windows*1e9 + floor((rem*1e9 + nsec) / size), the last term being below 1e9.
+	n := int64(size)
+	sec := v.Unix()
+	windows := sec / n
+	if sec%n < 0 {
+		windows--
+	}
+	hi, lo := bits.Mul64(uint64(sec-windows*n), uint64(time.Second))
+	lo, carry := bits.Add64(lo, uint64(v.Nanosecond()), 0)
+	within, _ := bits.Div64(hi+carry, lo, uint64(n))
+	if windows >= 0 {
+		hi, lo = bits.Mul64(uint64(windows), uint64(time.Second))
+		lo, carry = bits.Add64(lo, within, 0)
+		return formatUint128(hi+carry, lo, 36)
+	}
+	// A negative index is -(|windows|*1e9 - within), with |windows|*1e9 > within.
+	hi, lo = bits.Mul64(uint64(-windows), uint64(time.Second))
+	lo, borrow := bits.Sub64(lo, within, 0)
+	return "-" + formatUint128(hi-borrow, lo, 36)
+}
+
+// BusinessDiff measures the business time between `a` and `b` and decomposes it into whole business days
+// plus a leftover duration, which is convenient for SLA reports (e.g., "2 business days and 3h").
+//
+// Business time is the part of the span falling within the daily window [dayStart, dayEnd) on weekdays
+// (Monday to Friday), evaluated on calendar days in the location `loc`. A business day is one full window,
+// i.e., dayEnd - dayStart. When `b` is before `a`, both results are negative. When the window is empty
+// (dayEnd not after dayStart), both results are zero.
+//
+// Parameters:
+//
+//   - `a`: The start of the span.
+//
+//   - `b`: The end of the span.
+//
+//   - `dayStart`: The opening time of each business day.
+//
+//   - `dayEnd`: The closing time of each business day.
+//
+//   - `loc`: A pointer to a time.Location in which the business hours apply; nil keeps the location of `a`.
+//
+// Returns:
+//
+//   - An integer value representing the number of whole business days.
+//
+//   - A time.Duration value representing the business time left over after the whole days.
+//
+// Example:
+//
+//	a := time.Date(2023, time.October, 27, 15, 0, 0, 0, time.UTC) // Friday 15:00
+//	b := time.Date(2023, time.October, 31, 11, 0, 0, 0, time.UTC) // Tuesday 11:00
+//	days, rest := BusinessDiff(a, b, ClockTime{Hour: 9}, ClockTime{Hour: 17}, time.UTC) // This will return 1, 4h.
+func BusinessDiff(a, b time.Time, dayStart, dayEnd ClockTime, loc *time.Location) (businessDays int, remainder time.Duration) {
+	length := sinceMidnight(dayEnd) - sinceMidnight(dayStart)
+	if length <= 0 {
+		return 0, 0
+	}
+	total := windowedDuration(a, b, dayStart, dayEnd, loc, true)
+	return int(total / length), total % length
+}
+
+// DiffParts returns the calendar-accurate difference between `from` and `to`, broken down into years, months,
+// days, hours, minutes and seconds, the way a human would count it (e.g., "3 years, 2 months, 5 days").
+//
+// The function counts the largest number of whole months that can be added to `from` without passing `to`,
+// clamping the day of month when it does not exist (January 31st + 1 month => February 29th in 2020). It then
+// counts whole calendar days from that anchor, and expresses the remaining elapsed time in hours, minutes
+// and seconds. `to` is evaluated in the location of `from`. Fractions of a second are discarded.
+//
+// When `from` is after `to`, the difference is computed from `to` to `from` and every part is negated,
+// so all the returned values are zero or negative.
+//
+// Parameters:
+//
+//   - `from`: The start of the span.
+//
+//   - `to`: The end of the span.
+//
+// Returns:
+//
+//   - Integer values representing the years, months, days, hours, minutes and seconds of the difference.
+//
+// Example:
+//
+//	from := time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)
+//	to := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+//	y, mo, d, h, mi, s := DiffParts(from, to) // This will return 0, 1, 1, 0, 0, 0.
+func DiffParts(from, to time.Time) (years, months, days, hours, minutes, seconds int) {
+	sign := 1
+	if from.After(to) {
+		from, to = to, from
+		sign = -1
+	}
+	to = to.In(from.Location())
+	total := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	anchor := addMonthsClamped(from, total)
+	if anchor.After(to) {
+		total--
+		anchor = addMonthsClamped(from, total)
+	}
+	days = int(to.Sub(anchor) / (24 * time.Hour))
+	for !anchor.AddDate(0, 0, days+1).After(to) {
+		days++
+	}
+	for days > 0 && anchor.AddDate(0, 0, days).After(to) {
+		days--
+	}
+	rest := to.Sub(anchor.AddDate(0, 0, days))
+	hours = int(rest / time.Hour)
+	minutes = int(rest % time.Hour / time.Minute)
+	seconds = int(rest % time.Minute / time.Second)
+	years, months = total/12, total%12
+	return sign * years, sign * months, sign * days, sign * hours, sign * minutes, sign * seconds
+}
+
+// FormatICS formats the provided time value `v` as an iCalendar (RFC 5545) UTC timestamp,
+// e.g., 20230815T134530Z, as used by DTSTART/DTEND properties in .ics files.
+//
+// The time is converted to UTC before formatting and fractions of a second are discarded.
+//
+// Parameters:
+//
+//   - `v`: The time.Time value to format.
+//
+// Returns:
+//
+//   - A string containing the iCalendar UTC timestamp.
+//
+// Example:
+//
+//	v := time.Date(2023, time.August, 15, 20, 45, 30, 0, time.FixedZone("ICT", 7*3600))
+//	s := FormatICS(v) // This will return "20230815T134530Z".
+func FormatICS(v time.Time) string {
+	return v.UTC().Format(string(TimeFormat20060102T150405ZICS))
+}
+
+// FormatICSLocal formats the provided time value `v` as an iCalendar (RFC 5545) local timestamp without
+// the "Z" suffix, e.g., 20230815T204530, together with the name of its location to be used as TZID.
+//
+// The timestamp is expressed in the location of `v`. In an .ics file, the pair is written as
+// DTSTART;TZID=<tzid>:<value>. The TZID is only meaningful when `v` carries an IANA location
+// (e.g., "Asia/Ho_Chi_Minh"); for time.Local, the name is "Local".
+//
+// Parameters:
+//
+//   - `v`: The time.Time value to format.
+//
+// Returns:
+//
+//   - A string containing the iCalendar local timestamp.
+//
+//   - A string containing the name of the location of `v`.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+//	v := time.Date(2023, time.August, 15, 20, 45, 30, 0, loc)
+//	value, tzid := FormatICSLocal(v) // This will return "20230815T204530", "Asia/Ho_Chi_Minh".
+func FormatICSLocal(v time.Time) (value string, tzid string) {
+	return v.Format(string(TimeFormat20060102T150405ICS)), v.Location().String()
+}
+
+// Age returns the age, in completed years, of a person born at `birth`, as of the current time.
+//
+// It is a shorthand for AgeAt(birth, now), see AgeAt for the handling of birthdays that have not
+// occurred yet and of February 29th birthdays.
+//
+// Parameters:
+//
+//   - `birth`: The time.Time value representing the date of birth.
+//
+// Returns:
+//
+//   - An integer value representing the number of completed years.
+//
+// Example:
+//
+//	birth := time.Date(1990, time.May, 20, 0, 0, 0, 0, time.UTC)
+//	age := Age(birth) // This will return the current age of a person born on May 20, 1990.
+func Age(birth time.Time) int {
+	return AgeAt(birth, now())
+}
+
+// AgeAt returns the age, in completed years, of a person born at `birth`, as of the time `at`.
+//
+// The age only increments once the birthday has been reached: a person born on December 31st is
+// still the same age on January 1st. Dates are compared on the calendar in the location of `birth`,
+// ignoring the time of day. For a person born on February 29th, the birthday is treated as
+// February 28th in non-leap years. The result is negative when `at` is before `birth`.
+//
+// Parameters:
+//
+//   - `birth`: The time.Time value representing the date of birth.
+//
+//   - `at`: The time.Time value at which the age is evaluated.
+//
+// Returns:
+//
+//   - An integer value representing the number of completed years.
+//
+// Example:
+//
+//	birth := time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC)
+//	age := AgeAt(birth, time.Date(2021, time.February, 27, 0, 0, 0, 0, time.UTC)) // This will return 20.
+//	age = AgeAt(birth, time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC))  // This will return 21.
+func AgeAt(birth, at time.Time) int {
+	return completedYears(birth, at)
+}
+
+// ParseICS parses an iCalendar (RFC 5545) timestamp, as produced by FormatICS or FormatICSLocal.
+//
+// Two forms are accepted:
+//   - The UTC form "20060102T150405Z" (e.g., 20230815T134530Z), returned in UTC.
+//   - The floating form "20060102T150405" (e.g., 20230815T204530), interpreted in time.Local.
+//
+// Surrounding whitespace is ignored. To interpret a floating timestamp in its TZID location instead,
+// parse it with time.ParseInLocation and the TimeFormat20060102T150405ICS layout.
+//
+// Parameters:
+//
+//   - `s`: The iCalendar timestamp to parse.
+//
+// Returns:
+//
+//   - A time.Time value representing the parsed timestamp.
+//
+//   - An error if `s` matches neither form.
+//
+// Example:
+//
+//	v, err := ParseICS("20230815T134530Z") // This will return 2023-08-15 13:45:30 UTC, nil.
+//	v, err = ParseICS("2023-08-15")        // This will return an error.
+func ParseICS(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if v, err := time.Parse(string(TimeFormat20060102T150405ZICS), s); err == nil {
+		return v.UTC(), nil
+	}
+	if v, err := time.ParseInLocation(string(TimeFormat20060102T150405ICS), s, time.Local); err == nil {
+		return v, nil
+	}
+	return time.Time{}, fmt.Errorf("can't parse string as ICS time: %v", s)
+}
+
+// FromUnix returns the UTC time corresponding to the given Unix time in seconds since January 1, 1970 UTC.
+//
+// Parameters:
+//
+//   - `sec`: The number of seconds since the Unix epoch.
+//
+// Returns:
+//
+//   - A time.Time value in UTC.
+//
+// Example:
+//
+//	v := FromUnix(1692107130) // This will return 2023-08-15 13:45:30 UTC.
+func FromUnix(sec int64) time.Time {
+	return time.Unix(sec, 0).UTC()
+}
+
+// FromUnixMilli returns the UTC time corresponding to the given Unix time in milliseconds since January 1, 1970 UTC.
+//
+// Parameters:
+//
+//   - `ms`: The number of milliseconds since the Unix epoch.
+//
+// Returns:
+//
+//   - A time.Time value in UTC.
+//
+// Example:
+//
+//	v := FromUnixMilli(1692107130123) // This will return 2023-08-15 13:45:30.123 UTC.
+func FromUnixMilli(ms int64) time.Time {
+	return time.UnixMilli(ms).UTC()
+}
+
+// FromUnixMicro returns the UTC time corresponding to the given Unix time in microseconds since January 1, 1970 UTC.
+//
+// Parameters:
+//
+//   - `us`: The number of microseconds since the Unix epoch.
+//
+// Returns:
+//
+//   - A time.Time value in UTC.
+//
+// Example:
+//
+//	v := FromUnixMicro(1692107130123456) // This will return 2023-08-15 13:45:30.123456 UTC.
+func FromUnixMicro(us int64) time.Time {
+	return time.UnixMicro(us).UTC()
+}
+
+// FromUnixAuto returns the UTC time corresponding to the Unix timestamp `n`, guessing its unit from its magnitude.
+//
+// The unit is picked from the absolute value of `n`:
+//   - below 1e11: seconds (up to year 5138),
+//   - below 1e14: milliseconds (from March 1973 up to year 5138),
+//   - below 1e17: microseconds (from March 1973 up to year 5138),
+//   - otherwise: nanoseconds (from March 1973 onward).
+//
+// The heuristic is reliable for timestamps between 1973 and 5138; for instants close to the epoch,
+// the unit is ambiguous and the explicit functions (FromUnix, FromUnixMilli, FromUnixMicro) should be used.
+//
+// Parameters:
+//
+//   - `n`: The Unix timestamp, in seconds, milliseconds, microseconds or nanoseconds.
+//
+// Returns:
+//
+//   - A time.Time value in UTC.
+//
+// Example:
+//
+//	v := FromUnixAuto(1692107130)          // Seconds, this will return 2023-08-15 13:45:30 UTC.
+//	v = FromUnixAuto(1692107130123)        // Milliseconds, this will return 2023-08-15 13:45:30.123 UTC.
+//	v = FromUnixAuto(1692107130123456789)  // Nanoseconds, this will return 2023-08-15 13:45:30.123456789 UTC.
+func FromUnixAuto(n int64) time.Time {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs >= 1e17 || abs < 0: // abs < 0 only when n is math.MinInt64
+		return time.Unix(0, n).UTC()
+	case abs >= 1e14:
+		return FromUnixMicro(n)
+	case abs >= 1e11:
+		return FromUnixMilli(n)
+	default:
+		return FromUnix(n)
+	}
+}
+
+// SinceDailyReset returns how much time has elapsed between the most recent midnight in the timezone
+// `resetZone` and the time value `v`.
+//
+// This is intended for daily quotas that reset at local midnight in a business timezone while the
+// timestamps themselves are stored in UTC: the same instant can be early in the day in one zone and late
+// in another. The midnight is computed on the calendar date of `v` converted to `resetZone`, so days
+// shortened or lengthened by a DST transition are measured in actual elapsed time.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to evaluate.
+//
+//   - `resetZone`: A ZoneRFC value representing the IANA timezone in which the daily reset happens (e.g., DefaultTimezoneVietnam).
+//
+// Returns:
+//
+//   - A time.Duration value representing the time elapsed since the last reset.
+//
+//   - An error value, which will be non-nil if the timezone is invalid.
+//
+// Example:
+//
+//	v := time.Date(2023, time.August, 15, 20, 0, 0, 0, time.UTC)
+//	d, err := SinceDailyReset(v, "UTC")        // This will return 20h, nil.
+//	d, err = SinceDailyReset(v, "Asia/Tokyo")  // This will return 5h, nil (it is 05:00 on August 16 in Tokyo).
+func SinceDailyReset(v time.Time, resetZone ZoneRFC) (time.Duration, error) {
+	loc, err := loadLocation(string(resetZone))
+	if err != nil {
+		return 0, err
+	}
+	local := v.In(loc)
+	return local.Sub(With(local).BeginningOfDay()), nil
+}
+
+// TruncateTo returns the result of rounding `v` down to a multiple of `d`, measured from the beginning of
+// the day of `v` in its own location.
+//
+// Unlike time.Time.Truncate, which operates on the absolute time since the zero time (in effect, in UTC),
+// the multiples are counted from local midnight. For example, truncating 2023-10-25 10:40 +05:30 to 1 hour
+// gives 10:00 +05:30, whereas the stdlib gives 10:30 +05:30. The elapsed time since midnight is measured as
+// actual elapsed time, so on days with a DST transition multiples are counted from the real start of the day
+// and may not land on round wall-clock values. Multiples of 24 hours are special-cased to always give local
+// midnight, including on a 25-hour fall-back day, where 24 hours after midnight is still 23:00 of the same day.
+// If `d` is zero or negative, `v` is returned unchanged.
+//
+// Parameters:
+//
+//   - `v`: The time.Time value to truncate.
+//
+//   - `d`: The step to truncate to (e.g., 15 * time.Minute).
+//
+// Returns:
+//
+//   - A time.Time value representing `v` rounded down to the previous multiple of `d` since local midnight.
+//
+// Example:
+//
+//	loc := time.FixedZone("IST", 5*3600+1800)
+//	v := time.Date(2023, time.October, 25, 10, 40, 0, 0, loc)
+//	truncated := TruncateTo(v, time.Hour) // This will return 2023-10-25 10:00:00 +05:30.
+func TruncateTo(v time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return v
+	}
+	begin := With(v).BeginningOfDay()
+	if d%(24*time.Hour) == 0 {
+		return begin
+	}
+	elapsed := v.Sub(begin)
+	return begin.Add(elapsed - elapsed%d)
+}
+
+// RoundTo returns the result of rounding `v` to the nearest multiple of `d`, measured from the beginning of
+// the day of `v` in its own location.
+//
+// Unlike time.Time.Round, which operates on the absolute time since the zero time (in effect, in UTC),
+// the multiples are counted from local midnight, see TruncateTo. Halfway values are rounded up. Multiples of
+// 24 hours round to the nearest local midnight, which is the next one past the middle of a 23- or 25-hour day.
+// If `d` is zero or negative, `v` is returned unchanged.
+//
+// Parameters:
+//
+//   - `v`: The time.Time value to round.
+//
+//   - `d`: The step to round to (e.g., 15 * time.Minute).
+//
+// Returns:
+//
+//   - A time.Time value representing `v` rounded to the nearest multiple of `d` since local midnight.
+//
+// Example:
+//
+//	loc := time.FixedZone("IST", 5*3600+1800)
+//	v := time.Date(2023, time.October, 25, 10, 40, 0, 0, loc)
+//	rounded := RoundTo(v, time.Hour)     // This will return 2023-10-25 11:00:00 +05:30.
+//	rounded = RoundTo(v, 15*time.Minute) // This will return 2023-10-25 10:45:00 +05:30.
+func RoundTo(v time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return v
+	}
+	begin := With(v).BeginningOfDay()
+	if d%(24*time.Hour) == 0 {
+		y, m, day := v.Date()
+		if next := startOfDay(y, m, day+1, v.Location()); next.Sub(v) <= v.Sub(begin) {
+			return next
+		}
+		return begin
+	}
+	return begin.Add(v.Sub(begin).Round(d))
+}
+
+// IsWeekdayOf checks if the provided time value `v` falls on the specified weekday.
+//
+// It utilizes the With() function to wrap `v` and then applies the Is() method, so the weekday
+// is evaluated in the location of `v`.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to check.
+//
+//   - `weekday`: The time.Weekday value to compare with.
+//
+// Returns:
+//
+//   - A boolean value: true if `v` falls on `weekday`; false otherwise.
+//
+// Example:
+//
+//	v := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC) // Wednesday
+//	ok := IsWeekdayOf(v, time.Wednesday) // This will return true.
+func IsWeekdayOf(v time.Time, weekday time.Weekday) bool {
+	return With(v).Is(weekday)
+}
+
+// PeriodBoundariesInRange returns how many period starts (e.g., midnights for PeriodDay, first days of the
+// month for PeriodMonth) fall within the half-open interval (start, end], which is the number of additional
+// buckets a batch job aggregating by `period` will produce over the range.
+//
+// Both bounds are converted to `loc` before the boundaries are computed; if `loc` is nil, the location of
+// `start` is used. Week boundaries follow the default week start day (see With()). Each boundary is derived
+// from the previous one and is strictly later, so DST transitions are counted as they happen on the clock:
+// a fall-back day has 25 hour boundaries and a spring-forward day 23. The function returns 0 when `end` is
+// not after `start` or when the period is not recognized.
+//
+// Parameters:
+//
+//   - `start`: The exclusive lower bound of the interval.
+//
+//   - `end`: The inclusive upper bound of the interval.
+//
+//   - `period`: A Period value (e.g., PeriodDay, PeriodMonth) identifying the calendar unit.
+//
+//   - `loc`: A pointer to a time.Location used to determine the period boundaries.
+//
+// Returns:
+//
+//   - An integer value representing the number of period starts in (start, end].
+//
+// Example:
+//
+//	start := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)
+//	count := PeriodBoundariesInRange(start, start.AddDate(0, 0, 3), PeriodDay, time.UTC)   // This will return 3.
+//	count = PeriodBoundariesInRange(start, start.AddDate(0, 0, 90), PeriodMonth, time.UTC) // This will return 3.
+func PeriodBoundariesInRange(start, end time.Time, period Period, loc *time.Location) int {
+	if loc == nil {
+		loc = start.Location()
+	}
+	start, end = start.In(loc), end.In(loc)
+	if !end.After(start) {
+		return 0
+	}
+	count := 0
+	boundary := With(start).BeginningOf(period)
+	for {
+		next, ok := periodStep(boundary, period)
+		if !ok {
+			return count
+		}
+		// Re-snapping a repeated wall clock (e.g., 01:00 on a fall-back day) may resolve to an earlier
+		// instant, so the snapped value is only used when it moves strictly forward.
+		if snapped := With(next).BeginningOf(period); snapped.After(boundary) {
+			next = snapped
+		}
+		boundary = next
+		if boundary.After(end) {
+			return count
+		}
+		if boundary.After(start) {
+			count++
+		}
+	}
+}
+
+// IsSameDay checks if the provided time values `a` and `b` fall on the same calendar day.
+//
+// Both values are compared in the location of `a` (b is converted with b.In(a.Location())), and only the
+// year, month and day components are compared, so different clock times on the same date still match.
+// Two instants on the same UTC day may therefore fall on different days when `a` is in another location.
+//
+// Parameters:
+//
+//   - `a`: A time.Time value whose location is used for the comparison.
+//
+//   - `b`: A time.Time value to compare with `a`.
+//
+// Returns:
+//
+//   - A boolean value: true if both values fall on the same date in the location of `a`; false otherwise.
+//
+// Example:
+//
+//	a := time.Date(2023, time.October, 25, 1, 0, 0, 0, time.UTC)
+//	b := time.Date(2023, time.October, 25, 23, 0, 0, 0, time.UTC)
+//	same := IsSameDay(a, b) // This will return true.
+func IsSameDay(a, b time.Time) bool {
+	return dateKey(a) == dateKey(b.In(a.Location()))
+}
+
+// IsSameMonth checks if the provided time values `a` and `b` fall in the same month of the same year.
+//
+// Both values are compared in the location of `a` (b is converted with b.In(a.Location())).
+//
+// Parameters:
+//
+//   - `a`: A time.Time value whose location is used for the comparison.
+//
+//   - `b`: A time.Time value to compare with `a`.
+//
+// Returns:
+//
+//   - A boolean value: true if both values fall in the same month in the location of `a`; false otherwise.
+//
+// Example:
+//
+//	a := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+//	b := time.Date(2023, time.October, 31, 0, 0, 0, 0, time.UTC)
+//	same := IsSameMonth(a, b) // This will return true.
+func IsSameMonth(a, b time.Time) bool {
+	b = b.In(a.Location())
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
+
+// IsSameYear checks if the provided time values `a` and `b` fall in the same year.
+//
+// Both values are compared in the location of `a` (b is converted with b.In(a.Location())).
+//
+// Parameters:
+//
+//   - `a`: A time.Time value whose location is used for the comparison.
+//
+//   - `b`: A time.Time value to compare with `a`.
+//
+// Returns:
+//
+//   - A boolean value: true if both values fall in the same year in the location of `a`; false otherwise.
+//
+// Example:
+//
+//	a := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+//	b := time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC)
+//	same := IsSameYear(a, b) // This will return true.
+func IsSameYear(a, b time.Time) bool {
+	return a.Year() == b.In(a.Location()).Year()
+}
+
+// RemainingFraction returns how much of the interval [start, end] is left at the time `at`, as a fraction
+// between 0 and 1, which is convenient for "time left" gauges.
+//
+// The result is 1 minus the elapsed fraction (at - start) / (end - start), clamped to [0, 1]: it is 1 at
+// or before `start` and 0 at or after `end`. For an empty or inverted interval, the function returns 1
+// before `end` and 0 otherwise.
+//
+// Parameters:
+//
+//   - `start`: The beginning of the interval.
+//
+//   - `end`: The end of the interval.
+//
+//   - `at`: The time at which the remaining fraction is evaluated.
+//
+// Returns:
+//
+//   - A float64 value in the range [0, 1].
+//
+// Example:
+//
+//	start := time.Date(2023, time.October, 25, 9, 0, 0, 0, time.UTC)
+//	end := start.Add(2 * time.Hour)
+//	left := RemainingFraction(start, end, start.Add(time.Hour)) // This will return 0.5.
+func RemainingFraction(start, end, at time.Time) float64 {
+	if !at.Before(end) {
+		return 0
+	}
+	if !at.After(start) {
+		return 1
+	}
+	return 1 - float64(at.Sub(start))/float64(end.Sub(start))
+}
+
+// Recur generates the occurrences of a recurring rule, starting at `start` and repeatedly applying the
+// caller-supplied `step` function (e.g., one day, one week, one month later) to the previous occurrence.
+//
+// The first occurrence is `start` itself. Occurrences are collected while they are not after `until` and
+// until `maxCount` occurrences have been produced, whichever comes first. A zero `until` disables the date
+// bound and a `maxCount` of zero or less disables the count bound; when both are disabled, the rule would
+// never end and nil is returned. Generation also stops if `step` does not move forward in time (returns a
+// value that is not after its input), which guards against endless loops.
+//
+// Parameters:
+//
+//   - `start`: The first occurrence.
+//
+//   - `step`: A function returning the occurrence following the one it receives.
+//
+//   - `until`: The inclusive upper bound of the occurrences, or the zero time for no bound.
+//
+//   - `maxCount`: The maximum number of occurrences, or zero for no bound.
+//
+// Returns:
+//
+//   - A slice of time.Time values representing the occurrences, in chronological order.
+//
+// Example:
+//
+//	start := time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC)
+//	monthly := func(v time.Time) time.Time { return With(v).AddMonths(1).Time }
+//	dates := Recur(start, monthly, time.Time{}, 3)          // This will return Jan 31, Feb 29 and Mar 29, 2024 at 09:00.
+//	dates = Recur(start, monthly, start.AddDate(0, 2, 0), 0) // Bounded by date instead of count.
+func Recur(start time.Time, step func(time.Time) time.Time, until time.Time, maxCount int) []time.Time {
+	if until.IsZero() && maxCount <= 0 {
+		return nil
+	}
+	var dates []time.Time
+	for v := start; until.IsZero() || !v.After(until); {
+		dates = append(dates, v)
+		if maxCount > 0 && len(dates) >= maxCount {
+			break
+		}
+		next := step(v)
+		if !next.After(v) {
+			break
+		}
+		v = next
+	}
+	return dates
+}
+
+// ZoneOffsetDiff returns how far ahead the timezone `b` is of the timezone `a` at the instant `v`,
+// i.e., the UTC offset of `b` minus the UTC offset of `a`.
+//
+// Each offset is evaluated at `v`, so the DST state of each zone at that instant is taken into account:
+// Tokyo is 9 hours ahead of London in winter, but only 8 hours ahead while London observes BST.
+// A negative result means that `b` is behind `a`.
+//
+// Parameters:
+//
+//   - `v`: The instant at which the offsets are evaluated.
+//
+//   - `a`: A ZoneRFC value representing the reference timezone (e.g., "Europe/London").
+//
+//   - `b`: A ZoneRFC value representing the compared timezone (e.g., "Asia/Tokyo").
+//
+// Returns:
+//
+//   - A time.Duration value representing the offset of `b` minus the offset of `a`.
+//
+//   - An error value, which will be non-nil if either timezone is invalid.
+//
+// Example:
+//
+//	v := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+//	diff, err := ZoneOffsetDiff(v, "Europe/London", "Asia/Tokyo") // This will return 8h, nil.
+func ZoneOffsetDiff(v time.Time, a, b ZoneRFC) (time.Duration, error) {
+	locA, err := loadLocation(string(a))
+	if err != nil {
+		return 0, err
+	}
+	locB, err := loadLocation(string(b))
+	if err != nil {
+		return 0, err
+	}
+	_, offsetA := v.In(locA).Zone()
+	_, offsetB := v.In(locB).Zone()
+	return time.Duration(offsetB-offsetA) * time.Second, nil
+}
+
+// NextBusinessTimeOfDay returns the next occurrence of the clock time `clock`, strictly after `ref`,
+// that falls on a weekday (Monday to Friday) in the location `loc`.
+//
+// If `ref` is on a weekday and the clock time has not passed yet, the result is today at that time;
+// otherwise, it is that time on the following business day (e.g., Friday afternoon gives Monday).
+// If `loc` is nil, the location of `ref` is used. Holidays are not taken into account.
+//
+// Parameters:
+//
+//   - `ref`: The reference time.Time value; the result is always after it.
+//
+//   - `clock`: The target time of day.
+//
+//   - `loc`: A pointer to a time.Location in which the time of day and weekdays are evaluated.
+//
+// Returns:
+//
+//   - A time.Time value representing the next business occurrence of the clock time, in `loc`.
+//
+// Example:
+//
+//	ref := time.Date(2023, time.October, 27, 15, 0, 0, 0, time.UTC) // Friday 15:00
+//	next := NextBusinessTimeOfDay(ref, ClockTime{Hour: 9}, time.UTC) // This will return Monday 2023-10-30 09:00:00 UTC.
+func NextBusinessTimeOfDay(ref time.Time, clock ClockTime, loc *time.Location) time.Time {
+	if loc != nil {
+		ref = ref.In(loc)
+	}
+	y, m, d := ref.Date()
+	for i := 0; ; i++ {
+		next := clockOn(y, m, d+i, ref.Location(), clock.Hour, clock.Minute, clock.Second)
+		if next.After(ref) && IsWeekday(next) {
+			return next
+		}
+	}
+}
+
+// FormatDurationUnits formats the duration `d` in a compact units notation without spaces, such as "1d2h3m4s",
+// which is suitable for log fields and identifiers.
+//
+// The units are days (24 hours), hours, minutes, seconds, milliseconds, microseconds and nanoseconds, with the
+// suffixes d, h, m, s, ms, us and ns. Only the `maxUnits` most significant units are kept, counted from the
+// largest non-zero unit, like significant digits: the remainder is truncated and zero-valued units are omitted
+// from the output (e.g., 1d0h3m with maxUnits = 2 gives "1d"). A `maxUnits` of zero or less keeps every unit.
+// A zero duration gives "0s", and negative durations are prefixed with "-".
+//
+// Parameters:
+//
+//   - `d`: The time.Duration value to format.
+//
+//   - `maxUnits`: The maximum number of significant units to render.
+//
+// Returns:
+//
+//   - A string containing the compact representation of the duration.
+//
+// Example:
+//
+//	d := 26*time.Hour + 3*time.Minute + 4*time.Second
+//	s := FormatDurationUnits(d, 2) // This will return "1d2h".
+//	s = FormatDurationUnits(d, 0)  // This will return "1d2h3m4s".
+func FormatDurationUnits(d time.Duration, maxUnits int) string {
+	if d == 0 {
+		return "0s"
+	}
+	var sb strings.Builder
+	// Work on the magnitude as an unsigned value so that math.MinInt64 is handled.
+	rest := uint64(d)
+	if d < 0 {
+		sb.WriteByte('-')
+		rest = uint64(-d)
+	}
+	units := []struct {
+		size   uint64
+		suffix string
+	}{
+		{uint64(24 * time.Hour), "d"},
+		{uint64(time.Hour), "h"},
+		{uint64(time.Minute), "m"},
+		{uint64(time.Second), "s"},
+		{uint64(time.Millisecond), "ms"},
+		{uint64(time.Microsecond), "us"},
+		{uint64(time.Nanosecond), "ns"},
+	}
+	used := 0
+	for _, u := range units {
+		n := rest / u.size
+		rest %= u.size
+		if n == 0 && used == 0 {
+			continue
+		}
+		used++
+		if n > 0 {
+			sb.WriteString(strconv.FormatUint(n, 10))
+			sb.WriteString(u.suffix)
+		}
+		if used == maxUnits {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// BusinessDayStart returns the start of the business day containing `v`, for businesses whose day starts
+// at `dayStartHour` rather than at midnight (e.g., at 04:00, so that late-night events belong to the
+// previous business day).
+//
+// The result is `dayStartHour`:00 on the calendar date of `v` if that time has been reached, and on the
+// previous calendar date otherwise: with a 04:00 start, 02:00 maps to 04:00 on the prior day while 05:00
+// maps to 04:00 on the same day. If `loc` is nil, the location of `v` is used.
+//
+// Parameters:
+//
+//   - `v`: The time.Time value to evaluate.
+//
+//   - `dayStartHour`: The hour (0-23) at which each business day starts.
+//
+//   - `loc`: A pointer to a time.Location in which the business days are evaluated.
+//
+// Returns:
+//
+//   - A time.Time value representing the start of the business day containing `v`, in `loc`.
+//
+// Example:
+//
+//	v := time.Date(2023, time.October, 25, 2, 0, 0, 0, time.UTC)
+//	start := BusinessDayStart(v, 4, time.UTC) // This will return 2023-10-24 04:00:00 UTC.
+func BusinessDayStart(v time.Time, dayStartHour int, loc *time.Location) time.Time {
+	if loc != nil {
+		v = v.In(loc)
+	}
+	y, m, d := v.Date()
+	start := clockOn(y, m, d, v.Location(), dayStartHour, 0, 0)
+	if start.After(v) {
+		start = clockOn(y, m, d-1, v.Location(), dayStartHour, 0, 0)
+	}
+	return start
+}
+
+// BusinessDate returns the calendar date, at midnight, of the business day containing `v` when business
+// days start at `dayStartHour` rather than at midnight. This is useful for bucketing shift-work or
+// hospitality data, where a 03:00 event belongs to the previous night.
+//
+// The date is the one of BusinessDayStart(v, dayStartHour, loc): with a 04:00 start, an event at 03:00 on
+// October 25th is assigned to October 24th. If `loc` is nil, the location of `v` is used.
+//
+// Parameters:
+//
+//   - `v`: The time.Time value to evaluate.
+//
+//   - `dayStartHour`: The hour (0-23) at which each business day starts.
+//
+//   - `loc`: A pointer to a time.Location in which the business days are evaluated.
+//
+// Returns:
+//
+//   - A time.Time value representing the start of the calendar day the business day belongs to, in `loc`.
+//
+// Example:
+//
+//	v := time.Date(2023, time.October, 25, 3, 0, 0, 0, time.UTC)
+//	date := BusinessDate(v, 4, time.UTC) // This will return 2023-10-24 00:00:00 UTC.
+func BusinessDate(v time.Time, dayStartHour int, loc *time.Location) time.Time {
+	start := BusinessDayStart(v, dayStartHour, loc)
+	y, m, d := start.Date()
+	return startOfDay(y, m, d, start.Location())
+}
+
+// NextWeekday returns the nearest date strictly after `v` that falls on the given weekday, at the same
+// clock time as `v`, independently of any week window (e.g., "next Tuesday").
+//
+// The function is strict: if `v` already falls on `day`, the result is one full week later rather than `v`
+// itself. The date is shifted with AddDate, so the wall clock is kept across DST transitions.
+//
+// Parameters:
+//
+//   - `v`: The reference time.Time value.
+//
+//   - `day`: The target time.Weekday.
+//
+// Returns:
+//
+//   - A time.Time value, 1 to 7 days after `v`, falling on `day`.
+//
+// Example:
+//
+//	v := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC) // Wednesday
+//	next := NextWeekday(v, time.Tuesday)  // This will return 2023-10-31 10:00:00 UTC.
+//	next = NextWeekday(v, time.Wednesday) // This will return 2023-11-01 10:00:00 UTC.
+func NextWeekday(v time.Time, day time.Weekday) time.Time {
+	n := (int(day) - int(v.Weekday()) + 7) % 7
+	if n == 0 {
+		n = 7
+	}
+	return v.AddDate(0, 0, n)
+}
+
+// PreviousWeekday returns the nearest date strictly before `v` that falls on the given weekday, at the same
+// clock time as `v` (e.g., "last Tuesday").
+//
+// The function is strict: if `v` already falls on `day`, the result is one full week earlier rather than `v`
+// itself. The date is shifted with AddDate, so the wall clock is kept across DST transitions.
+//
+// Parameters:
+//
+//   - `v`: The reference time.Time value.
+//
+//   - `day`: The target time.Weekday.
+//
+// Returns:
+//
+//   - A time.Time value, 1 to 7 days before `v`, falling on `day`.
+//
+// Example:
+//
+//	v := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC) // Wednesday
+//	prev := PreviousWeekday(v, time.Tuesday)  // This will return 2023-10-24 10:00:00 UTC.
+//	prev = PreviousWeekday(v, time.Wednesday) // This will return 2023-10-18 10:00:00 UTC.
+func PreviousWeekday(v time.Time, day time.Weekday) time.Time {
+	n := (int(v.Weekday()) - int(day) + 7) % 7
+	if n == 0 {
+		n = 7
+	}
+	return v.AddDate(0, 0, -n)
+}
+
+// DaytimeBetween returns the part of the time between `start` and `end` that falls within the daily window
+// [dayStart, dayEnd), e.g., to meter "active hours" while excluding nights.
+//
+// Each calendar day touched by the span, evaluated in the location `loc`, contributes its overlap with the
+// window. Unlike BusinessDiff, weekends are included. If `loc` is nil, the location of `start` is used.
+// The result is negative when `end` is before `start`, and zero when the window is empty.
+//
+// Parameters:
+//
+//   - `start`: The start of the span.
+//
+//   - `end`: The end of the span.
+//
+//   - `dayStart`: The time of day at which the daily window opens.
+//
+//   - `dayEnd`: The time of day at which the daily window closes.
+//
+//   - `loc`: A pointer to a time.Location in which the daily window applies.
+//
+// Returns:
+//
+//   - A time.Duration value representing the time spent within the daily windows.
+//
+// Example:
+//
+//	start := time.Date(2023, time.October, 27, 18, 0, 0, 0, time.UTC) // Friday 18:00
+//	end := time.Date(2023, time.October, 29, 10, 0, 0, 0, time.UTC)   // Sunday 10:00
+//	d := DaytimeBetween(start, end, ClockTime{Hour: 8}, ClockTime{Hour: 20}, time.UTC) // This will return 16h (2h + 12h + 2h).
+func DaytimeBetween(start, end time.Time, dayStart, dayEnd ClockTime, loc *time.Location) time.Duration {
+	return windowedDuration(start, end, dayStart, dayEnd, loc, false)
+}
+
+// Countdown returns the time remaining until `target`, measured from the current time, broken down into
+// days, hours, minutes and seconds for countdown timers.
+//
+// It is a shorthand for CountdownFrom with the current time read from the package clock (see SetClock and
+// FreezeTime), see CountdownFrom for the details.
+//
+// Parameters:
+//
+//   - `target`: The time.Time value the countdown runs to.
+//
+// Returns:
+//
+//   - Integer values representing the remaining days, hours, minutes and seconds.
+//
+//   - A boolean value indicating whether the target has been reached.
+//
+// Example:
+//
+//	FreezeTime(time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC))
+//	target := time.Date(2023, time.October, 26, 12, 3, 4, 0, time.UTC)
+//	days, hours, minutes, seconds, expired := Countdown(target) // This will return 1, 2, 3, 4, false.
+func Countdown(target time.Time) (days, hours, minutes, seconds int, expired bool) {
+	return CountdownFrom(now(), target)
+}
+
+// CountdownFrom returns the time remaining from `from` until `target`, broken down into days, hours,
+// minutes and seconds for countdown timers.
+//
+// Each field is the remainder after the larger units (e.g., 90 seconds gives 1 minute and 30 seconds),
+// and days are 24-hour periods of elapsed time. Fractions of a second are discarded. When `target` is not
+// after `from`, the countdown is over: every field is zero and `expired` is true.
+//
+// Parameters:
+//
+//   - `from`: The time.Time value from which the remaining time is measured.
+//
+//   - `target`: The time.Time value the countdown runs to.
+//
+// Returns:
+//
+//   - Integer values representing the remaining days, hours, minutes and seconds.
+//
+//   - A boolean value indicating whether the target has been reached.
+//
+// Example:
+//
+//	from := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)
+//	target := from.Add(26*time.Hour + 3*time.Minute + 4*time.Second)
+//	days, hours, minutes, seconds, expired := CountdownFrom(from, target) // This will return 1, 2, 3, 4, false.
+func CountdownFrom(from, target time.Time) (days, hours, minutes, seconds int, expired bool) {
+	d := target.Sub(from)
+	if d <= 0 {
+		return 0, 0, 0, 0, true
+	}
+	days = int(d / (24 * time.Hour))
+	hours = int(d % (24 * time.Hour) / time.Hour)
+	minutes = int(d % time.Hour / time.Minute)
+	seconds = int(d % time.Minute / time.Second)
+	return days, hours, minutes, seconds, false
+}
+
+// IsEmpty checks if the provided time value `v` is the zero time.Time (January 1, year 1, 00:00:00 UTC),
+// which usually denotes a missing value rather than an actual date.
+//
+// It is equivalent to v.IsZero(), named for readability in guards against meaningless year-1 results.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to check.
+//
+// Returns:
+//
+//   - A boolean value: true if `v` is the zero time; false otherwise.
+//
+// Example:
+//
+//	var v time.Time
+//	empty := IsEmpty(v) // This will return true.
+func IsEmpty(v time.Time) bool {
+	return v.IsZero()
+}
+
+// NthWeekdayOfMonth returns midnight (in the local time zone) of the nth occurrence of the given weekday in
+// the given month, e.g., the 2nd Tuesday, for recurring events defined that way.
+//
+// Only n from 1 through 5 can exist. When the month has no such occurrence (e.g., a 5th Monday in a month with
+// four Mondays) or `n` is out of range, the zero time and false are returned.
+//
+// Parameters:
+//
+//   - `year`: An integer representing the year.
+//
+//   - `month`: A time.Month value representing the month.
+//
+//   - `weekday`: The time.Weekday to look for.
+//
+//   - `n`: The 1-based occurrence of `weekday` within the month.
+//
+// Returns:
+//
+//   - A time.Time value representing the start of the matching day.
+//
+//   - A boolean value indicating whether the occurrence exists.
+//
+// Example:
+//
+//	v, ok := NthWeekdayOfMonth(2023, time.October, time.Tuesday, 2) // This will return 2023-10-10 00:00:00, true.
+//	v, ok = NthWeekdayOfMonth(2023, time.November, time.Monday, 5)  // This will return the zero time, false.
+func NthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, n int) (time.Time, bool) {
+	if n < 1 || n > 5 {
+		return time.Time{}, false
+	}
+	first := time.Date(year, month, 1, 12, 0, 0, 0, time.UTC).Weekday()
+	day := 1 + (int(weekday)-int(first)+7)%7 + (n-1)*7
+	if day > DaysInMonth(year, month) {
+		return time.Time{}, false
+	}
+	return startOfDay(year, month, day, time.Local), true
+}
+
+// LastWeekdayOfMonth returns midnight (in the local time zone) of the last occurrence of the given weekday
+// in the given month, e.g., the last Friday.
+//
+// Parameters:
+//
+//   - `year`: An integer representing the year.
+//
+//   - `month`: A time.Month value representing the month.
+//
+//   - `weekday`: The time.Weekday to look for.
+//
+// Returns:
+//
+//   - A time.Time value representing the start of the last matching day of the month.
+//
+// Example:
+//
+//	v := LastWeekdayOfMonth(2023, time.October, time.Friday) // This will return 2023-10-27 00:00:00.
+func LastWeekdayOfMonth(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := DaysInMonth(year, month)
+	lastWeekday := time.Date(year, month, last, 12, 0, 0, 0, time.UTC).Weekday()
+	day := last - (int(lastWeekday)-int(weekday)+7)%7
+	return startOfDay(year, month, day, time.Local)
+}
+
+// AtTime returns the date of the provided time value `v` at the given clock time, keeping the year, month, day
+// and location of `v` and replacing its hour, minute, second and nanosecond.
+//
+// Out-of-range components are not rejected but roll over, exactly as with time.Date: hour=25 is 01:00 on
+// the next day, min=90 is half past the next hour, and negative values roll back (e.g., hour=-1 is 23:00 on
+// the previous day). Validate the inputs beforehand (e.g., with ClockTime.Valid) when rollover is not wanted.
+// An in-range clock time skipped by a DST transition resolves to the same elapsed time after midnight (e.g.,
+// 02:30 on a spring-forward day becomes 03:30).
+//
+// Parameters:
+//
+//   - `v`: The time.Time value providing the date and location.
+//
+//   - `hour`: The hour of the day (0-23).
+//
+//   - `min`: The minute of the hour (0-59).
+//
+//   - `sec`: The second of the minute (0-59).
+//
+//   - `nsec`: The nanosecond of the second (0-999999999).
+//
+// Returns:
+//
+//   - A time.Time value representing the same date at the requested clock time.
+//
+// Example:
+//
+//	v := time.Date(2023, time.October, 25, 17, 30, 0, 0, time.UTC)
+//	at := AtTime(v, 9, 0, 0, 0) // This will return 2023-10-25 09:00:00 UTC.
+//	at = AtTime(v, 25, 0, 0, 0) // This will return 2023-10-26 01:00:00 UTC.
+func AtTime(v time.Time, hour, min, sec, nsec int) time.Time {
+	y, m, d := v.Date()
+	if hour < 0 || hour > 23 || min < 0 || min > 59 || sec < 0 || sec > 59 || nsec < 0 || nsec >= int(time.Second) {
+		return time.Date(y, m, d, hour, min, sec, nsec, v.Location())
+	}
+	return clockOn(y, m, d, v.Location(), hour, min, sec).Add(time.Duration(nsec))
+}
+
+// Min returns the earlier of the two provided time values.
+//
+// The instants are compared with Before, so the locations are irrelevant; when both denote the same instant,
+// `a` is returned.
+//
+// Parameters:
+//
+//   - `a`: The first time.Time value.
+//
+//   - `b`: The second time.Time value.
+//
+// Returns:
+//
+//   - A time.Time value representing the earlier of `a` and `b`.
+//
+// Example:
+//
+//	a := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)
+//	b := time.Date(2023, time.October, 24, 10, 0, 0, 0, time.UTC)
+//	v := Min(a, b) // This will return 2023-10-24 10:00:00 UTC.
+func Min(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// Max returns the later of the two provided time values.
+//
+// The instants are compared with After, so the locations are irrelevant; when both denote the same instant,
+// `a` is returned.
+//
+// Parameters:
+//
+//   - `a`: The first time.Time value.
+//
+//   - `b`: The second time.Time value.
+//
+// Returns:
+//
+//   - A time.Time value representing the later of `a` and `b`.
+//
+// Example:
+//
+//	a := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)
+//	b := time.Date(2023, time.October, 24, 10, 0, 0, 0, time.UTC)
+//	v := Max(a, b) // This will return 2023-10-25 10:00:00 UTC.
+func Max(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// Clamp restricts the provided time value `v` to the closed interval [lo, hi]: it returns `lo` if `v` is
+// before `lo`, `hi` if `v` is after `hi`, and `v` otherwise.
+//
+// If the bounds are given in the wrong order (`lo` after `hi`), they are swapped, so the result always lies
+// between the two bounds regardless of their order.
+//
+// Parameters:
+//
+//   - `v`: The time.Time value to clamp.
+//
+//   - `lo`: The lower bound.
+//
+//   - `hi`: The upper bound.
+//
+// Returns:
+//
+//   - A time.Time value within [lo, hi].
+//
+// Example:
+//
+//	lo := time.Date(2023, time.October, 25, 9, 0, 0, 0, time.UTC)
+//	hi := time.Date(2023, time.October, 25, 17, 0, 0, 0, time.UTC)
+//	v := Clamp(time.Date(2023, time.October, 25, 20, 0, 0, 0, time.UTC), lo, hi) // This will return 17:00 UTC.
+//	v = Clamp(time.Date(2023, time.October, 25, 7, 0, 0, 0, time.UTC), hi, lo)   // This will return 09:00 UTC.
+func Clamp(v, lo, hi time.Time) time.Time {
+	if lo.After(hi) {
+		lo, hi = hi, lo
+	}
+	if v.Before(lo) {
+		return lo
+	}
+	if v.After(hi) {
+		return hi
+	}
+	return v
+}
+
+// Earliest returns the earliest instant among the provided time values, in any order.
+//
+// Zero-value times are not skipped: the zero time.Time is January 1, year 1, which is earlier than any
+// realistic timestamp, so it is returned whenever it is present. Filter such values beforehand (e.g., with
+// IsEmpty) if they denote missing data. When several values denote the same earliest instant, the first
+// of them is returned.
+//
+// Parameters:
+//
+//   - `times`: A variadic list of time.Time values.
+//
+// Returns:
+//
+//   - A time.Time value representing the earliest instant.
+//
+//   - A boolean value, false when `times` is empty (the time is then the zero value).
+//
+// Example:
+//
+//	a := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	b := time.Date(2023, time.October, 20, 0, 0, 0, 0, time.UTC)
+//	v, ok := Earliest(a, b) // This will return 2023-10-20 00:00:00 UTC, true.
+//	v, ok = Earliest()      // This will return the zero time, false.
+func Earliest(times ...time.Time) (time.Time, bool) {
+	if len(times) == 0 {
+		return time.Time{}, false
+	}
+	v := times[0]
+	for _, t := range times[1:] {
+		v = Min(v, t)
+	}
+	return v, true
+}
+
+// Latest returns the latest instant among the provided time values, in any order.
+//
+// Zero-value times are considered just like any other instant; as the zero time.Time is January 1, year 1,
+// it is only returned when every value is zero. When several values denote the same latest instant, the
+// first of them is returned.
+//
+// Parameters:
+//
+//   - `times`: A variadic list of time.Time values.
+//
+// Returns:
+//
+//   - A time.Time value representing the latest instant.
+//
+//   - A boolean value, false when `times` is empty (the time is then the zero value).
+//
+// Example:
+//
+//	a := time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)
+//	b := time.Date(2023, time.October, 20, 0, 0, 0, 0, time.UTC)
+//	v, ok := Latest(a, b) // This will return 2023-10-25 00:00:00 UTC, true.
+//	v, ok = Latest()      // This will return the zero time, false.
+func Latest(times ...time.Time) (time.Time, bool) {
+	if len(times) == 0 {
+		return time.Time{}, false
+	}
+	v := times[0]
+	for _, t := range times[1:] {
+		v = Max(v, t)
+	}
+	return v, true
+}
+
+// IsToday checks if the provided time value `v` falls on the current calendar day, in the location of `v`
+// (e.g., to label a timestamp "Today").
+//
+// The current time is read from the package clock (see SetClock and FreezeTime), so the result can be
+// made deterministic in tests. Use IsSameDay to compare against an explicit reference time instead.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to check.
+//
+// Returns:
+//
+//   - A boolean value: true if `v` falls on today's date in its location; false otherwise.
+//
+// Example:
+//
+//	FreezeTime(time.Date(2023, time.October, 25, 0, 30, 0, 0, time.UTC))
+//	today := IsToday(time.Date(2023, time.October, 25, 23, 59, 0, 0, time.UTC)) // This will return true.
+func IsToday(v time.Time) bool {
+	return IsSameDay(v, now())
+}
+
+// IsYesterday checks if the provided time value `v` falls on the calendar day before the current one, in the
+// location of `v` (e.g., to label a timestamp "Yesterday").
+//
+// The current time is read from the package clock (see SetClock and FreezeTime). Days are calendar days, so
+// 23:59 yesterday is "yesterday" even when it is only a minute ago.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to check.
+//
+// Returns:
+//
+//   - A boolean value: true if `v` falls on yesterday's date in its location; false otherwise.
+//
+// Example:
+//
+//	FreezeTime(time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC))
+//	yesterday := IsYesterday(time.Date(2023, time.October, 24, 23, 59, 0, 0, time.UTC)) // This will return true.
+func IsYesterday(v time.Time) bool {
+	y, m, d := now().In(v.Location()).Date()
+	return dateKey(v) == dateKey(time.Date(y, m, d-1, 0, 0, 0, 0, time.UTC))
+}
+
+// IsTomorrow checks if the provided time value `v` falls on the calendar day after the current one, in the
+// location of `v` (e.g., to label a timestamp "Tomorrow").
+//
+// The current time is read from the package clock (see SetClock and FreezeTime). Days are calendar days, so
+// 00:00 tomorrow is "tomorrow" even when it is only a minute away.
+//
+// Parameters:
+//
+//   - `v`: A time.Time value representing the time to check.
+//
+// Returns:
+//
+//   - A boolean value: true if `v` falls on tomorrow's date in its location; false otherwise.
+//
+// Example:
+//
+//	FreezeTime(time.Date(2023, time.October, 25, 23, 59, 0, 0, time.UTC))
+//	tomorrow := IsTomorrow(time.Date(2023, time.October, 26, 0, 0, 0, 0, time.UTC)) // This will return true.
+func IsTomorrow(v time.Time) bool {
+	y, m, d := now().In(v.Location()).Date()
+	return dateKey(v) == dateKey(time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC))
+}
+
+// IterateRange calls `fn` for each instant from `start` to `end` inclusive, stepping by the fixed duration
+// `step`, without materializing the instants in a slice (e.g., for large ranges).
+//
+// The iteration stops early as soon as `fn` returns false. Nothing is called when `end` is before `start`
+// or when `step` is not positive, which would never reach `end`. As the step is elapsed time, a 24-hour step
+// shifts the wall clock across DST transitions; use IterateDays to step by calendar days.
+//
+// Parameters:
+//
+//   - `start`: The first instant of the range.
+//
+//   - `end`: The last instant of the range (inclusive).
+//
+//   - `step`: The time.Duration between two consecutive instants.
+//
+//   - `fn`: The callback invoked for each instant; returning false stops the iteration.
+//
+// Example:
+//
+//	start := time.Date(2023, time.October, 25, 9, 0, 0, 0, time.UTC)
+//	IterateRange(start, start.Add(2*time.Hour), 30*time.Minute, func(v time.Time) bool {
+//		fmt.Println(v.Format("15:04")) // This will print 09:00, 09:30, 10:00, 10:30 and 11:00.
+//		return true
+//	})
+func IterateRange(start, end time.Time, step time.Duration, fn func(time.Time) bool) {
+	if step <= 0 {
+		return
+	}
+	for v := start; !v.After(end); v = v.Add(step) {
+		if !fn(v) {
+			return
+		}
+	}
+}
+
+// IterateDays calls `fn` for each day from `start` to `end` inclusive, at the clock time of `start`, without
+// materializing the days in a slice.
+//
+// Each day is computed from `start` with AddDate(0, 0, n), so the wall clock is kept across DST transitions (a
+// day may then last 23 or 25 hours). A clock time that does not exist on one day (it falls in a DST gap) is
+// normalized by time.Date on that day only, and does not shift the following days. The iteration stops early
+// as soon as `fn` returns false, and nothing is called when `end` is before `start`.
+//
+// Parameters:
+//
+//   - `start`: The first day of the range.
+//
+//   - `end`: The last instant of the range (inclusive).
+//
+//   - `fn`: The callback invoked for each day; returning false stops the iteration.
+//
+// Example:
+//
+//	start := time.Date(2023, time.October, 25, 9, 0, 0, 0, time.UTC)
+//	IterateDays(start, start.AddDate(0, 0, 6), func(v time.Time) bool {
+//		return !IsWeekend(v) // This will visit October 25 to 28, stopping at Saturday the 28th.
+//	})
+func IterateDays(start, end time.Time, fn func(time.Time) bool) {
+	for n, v := 0, start; !v.After(end); n, v = n+1, start.AddDate(0, 0, n+1) {
+		if !fn(v) {
+			return
+		}
+	}
 }
@@ -0,0 +1,98 @@
+package timefy
+
+import "time"
+
+// Start starts the stopwatch, or resumes it after Stop, accumulating with the time already measured.
+// Calling Start on a running stopwatch has no effect.
+//
+// Example:
+//
+//	var sw Stopwatch
+//	sw.Start()
+func (s *Stopwatch) Start() {
+	if s.running {
+		return
+	}
+	s.running = true
+	s.started = now()
+}
+
+// Stop pauses the stopwatch, keeping the elapsed time until Start resumes it or Reset clears it.
+// Stop is idempotent: calling it on a stopped stopwatch has no effect.
+//
+// Example:
+//
+//	sw.Stop()
+//	total := sw.Elapsed() // Does not change until the stopwatch is started again.
+func (s *Stopwatch) Stop() {
+	if !s.running {
+		return
+	}
+	s.elapsed += now().Sub(s.started)
+	s.running = false
+}
+
+// Reset stops the stopwatch and clears its elapsed time and laps.
+//
+// Example:
+//
+//	sw.Reset()
+//	total := sw.Elapsed() // This will return 0.
+func (s *Stopwatch) Reset() {
+	*s = Stopwatch{}
+}
+
+// Elapsed returns the total time measured by the stopwatch over all its runs. While the stopwatch is
+// running, the current run is included up to the current time of the package clock.
+//
+// Returns:
+//
+//   - A time.Duration value representing the elapsed time.
+//
+// Example:
+//
+//	FreezeTime(time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC))
+//	var sw Stopwatch
+//	sw.Start()
+//	FreezeTime(time.Date(2023, time.October, 25, 10, 0, 3, 0, time.UTC))
+//	total := sw.Elapsed() // This will return 3s.
+func (s *Stopwatch) Elapsed() time.Duration {
+	if s.running {
+		return s.elapsed + now().Sub(s.started)
+	}
+	return s.elapsed
+}
+
+// Lap records a lap: the elapsed time since the previous lap, or since the stopwatch was first started for
+// the first lap. The stopwatch keeps running (or stays stopped); time spent stopped is not counted.
+//
+// Returns:
+//
+//   - A time.Duration value representing the duration of the recorded lap.
+//
+// Example:
+//
+//	sw.Start()
+//	first := sw.Lap()  // Time since Start.
+//	second := sw.Lap() // Time since the first lap.
+func (s *Stopwatch) Lap() time.Duration {
+	total := s.Elapsed()
+	lap := total - s.lapMark
+	s.lapMark = total
+	s.laps = append(s.laps, lap)
+	return lap
+}
+
+// Laps returns the durations of the laps recorded with Lap since the last Reset, in order.
+// The returned slice is a copy and can be modified freely.
+//
+// Returns:
+//
+//   - A slice of time.Duration values representing the lap durations; it is empty when no lap was recorded.
+//
+// Example:
+//
+//	laps := sw.Laps() // This will return e.g. [1.2s 0.8s].
+func (s *Stopwatch) Laps() []time.Duration {
+	return append([]time.Duration(nil), s.laps...)
+}
@@ -1 +1,3013 @@
 package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sivaosorg/timefy"
+)
+
+// freezeClock freezes the package clock at v for the duration of the test.
+func freezeClock(t *testing.T, v time.Time) {
+	t.Helper()
+	timefy.FreezeTime(v)
+	t.Cleanup(func() { timefy.SetClock(nil) })
+}
+
+// loadLocation loads the named location or aborts the test.
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+// utc returns the given date and clock time in UTC.
+func utc(year int, month time.Month, day, hour, min, sec int) time.Time {
+	return time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+}
+
+func TestMustSetTimezone(t *testing.T) {
+	v := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)
+	if got := timefy.MustSetTimezone(v, "Asia/Tokyo"); got.Hour() != 19 || got.Location().String() != "Asia/Tokyo" {
+		t.Errorf("MustSetTimezone(Asia/Tokyo) = %v", got)
+	}
+	if got := timefy.MustLoadLocation("Europe/London"); got.String() != "Europe/London" {
+		t.Errorf("MustLoadLocation(Europe/London) = %v", got)
+	}
+	tests := []struct {
+		name string
+		call func(tz string)
+	}{
+		{"MustSetTimezone", func(tz string) { timefy.MustSetTimezone(v, tz) }},
+		{"MustLoadLocation", func(tz string) { timefy.MustLoadLocation(tz) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatal("no panic for an invalid zone")
+				}
+				if msg := fmt.Sprint(r); !strings.Contains(msg, `"Mars/Olympus"`) {
+					t.Errorf("panic message %q does not name the zone", msg)
+				}
+			}()
+			tt.call("Mars/Olympus")
+		})
+	}
+}
+
+func TestSetTimezoneInvalid(t *testing.T) {
+	v := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.FixedZone("X", 3600))
+	got, err := timefy.SetTimezone(v, "Mars/Olympus")
+	if err == nil {
+		t.Fatal("SetTimezone(Mars/Olympus) returned no error")
+	}
+	if got != v {
+		t.Errorf("SetTimezone(Mars/Olympus) = %v, want %v unchanged", got, v)
+	}
+}
+
+func TestSetTimezoneCached(t *testing.T) {
+	v := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+	for _, tz := range []string{"America/New_York", "Asia/Tokyo", "Europe/London", "UTC"} {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := v.In(loc)
+		for i := 0; i < 2; i++ { // the second call is served from the cache
+			got, err := timefy.SetTimezone(v, tz)
+			if err != nil || !got.Equal(want) || got.Location().String() != tz || got.Format(time.RFC3339) != want.Format(time.RFC3339) {
+				t.Errorf("SetTimezone(%s) call %d = %v, %v; want %v", tz, i+1, got, err, want)
+			}
+		}
+	}
+	for i := 0; i < 2; i++ { // invalid zones are not cached and keep failing like time.LoadLocation
+		_, want := time.LoadLocation("Mars/Olympus")
+		if _, err := timefy.SetTimezone(v, "Mars/Olympus"); err == nil || err.Error() != want.Error() {
+			t.Errorf("SetTimezone(Mars/Olympus) call %d error = %v, want %v", i+1, err, want)
+		}
+	}
+}
+
+func BenchmarkSetTimezone(b *testing.B) {
+	v := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = timefy.SetTimezone(v, "America/New_York")
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			loc, _ := time.LoadLocation("America/New_York")
+			_ = v.In(loc)
+		}
+	})
+}
+
+func TestPeriodStartUnix(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	tests := []struct {
+		v      time.Time
+		period timefy.Period
+		loc    *time.Location
+		want   func(x *timefy.Timex) time.Time
+	}{
+		{utc(2023, time.October, 25, 10, 42, 7), timefy.PeriodDay, time.UTC, (*timefy.Timex).BeginningOfDay},
+		{utc(2023, time.October, 25, 10, 42, 7), timefy.PeriodHour, time.UTC, (*timefy.Timex).BeginningOfHour},
+		{utc(2023, time.October, 25, 2, 42, 7), timefy.PeriodDay, ny, (*timefy.Timex).BeginningOfDay},
+		{utc(2023, time.October, 25, 2, 42, 7), timefy.PeriodHour, ny, (*timefy.Timex).BeginningOfHour},
+	}
+	for _, tt := range tests {
+		want := tt.want(timefy.With(tt.v.In(tt.loc))).Unix()
+		if got := timefy.PeriodStartUnix(tt.v, tt.period, tt.loc); got != want {
+			t.Errorf("PeriodStartUnix(%v, %v, %v) = %d, want %d", tt.v, tt.period, tt.loc, got, want)
+		}
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	start := utc(2022, time.October, 2, 9, 0, 0) // Sunday
+	for _, days := range []int{0, 1, 4, 5, 6, 7, 13, 30, 100, 365} {
+		for offset := 0; offset < 7; offset++ {
+			from := start.AddDate(0, 0, offset)
+			to := from.AddDate(0, 0, days)
+			if got, want := timefy.BusinessDaysBetween(from, to), len(timefy.GetWeekdaysInRange(from, to)); got != want {
+				t.Errorf("BusinessDaysBetween(%v, %v) = %d, want %d", from, to, got, want)
+			}
+		}
+	}
+	if got := timefy.BusinessDaysBetween(start.AddDate(0, 0, 3), start); got != 0 {
+		t.Errorf("BusinessDaysBetween(end before start) = %d, want 0", got)
+	}
+}
+
+func TestWeekElapsedFraction(t *testing.T) {
+	tests := []struct {
+		v         time.Time
+		weekStart time.Weekday
+		want      float64
+	}{
+		{utc(2024, time.October, 20, 0, 0, 0), time.Sunday, 0},    // Sunday start
+		{utc(2024, time.October, 23, 12, 0, 0), time.Sunday, 0.5}, // Wednesday noon
+		{utc(2024, time.October, 21, 0, 0, 0), time.Monday, 0},    // Monday start
+		{utc(2024, time.October, 24, 12, 0, 0), time.Monday, 0.5}, // Thursday noon
+		{utc(2024, time.October, 26, 23, 59, 59), time.Sunday, 1}, // last second of a Sunday week
+		{utc(2024, time.October, 27, 23, 59, 59), time.Monday, 1}, // last second of a Monday week
+	}
+	for _, tt := range tests {
+		got := timefy.WeekElapsedFraction(tt.v, tt.weekStart)
+		if got < 0 || got >= 1 || math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("WeekElapsedFraction(%v, %v) = %v, want ~%v", tt.v, tt.weekStart, got, tt.want)
+		}
+	}
+}
+
+func TestGetWorkdaysInRange(t *testing.T) {
+	start := utc(2023, time.December, 18, 0, 0, 0) // Monday
+	end := utc(2023, time.December, 31, 0, 0, 0)   // Sunday
+	tests := []struct {
+		name     string
+		holidays []time.Time
+		want     int
+	}{
+		{"no holiday", nil, 10},
+		{"weekday holiday with a time of day", []time.Time{time.Date(2023, time.December, 25, 15, 30, 0, 0, time.UTC)}, 9},
+		{"weekend holiday", []time.Time{utc(2023, time.December, 23, 0, 0, 0)}, 10},
+	}
+	for _, tt := range tests {
+		got := timefy.GetWorkdaysInRange(start, end, tt.holidays)
+		if len(got) != tt.want {
+			t.Errorf("%s: GetWorkdaysInRange returned %d days, want %d", tt.name, len(got), tt.want)
+		}
+		for _, d := range got {
+			if d.Month() == time.December && d.Day() == 25 && len(tt.holidays) > 0 && tt.holidays[0].Day() == 25 {
+				t.Errorf("%s: holiday %v was not excluded", tt.name, d)
+			}
+		}
+	}
+}
+
+func TestIsLeapDay(t *testing.T) {
+	tests := []struct {
+		v    time.Time
+		want bool
+	}{
+		{utc(2024, time.February, 29, 12, 0, 0), true},
+		{utc(2024, time.February, 28, 12, 0, 0), false},
+		{utc(2024, time.March, 1, 0, 0, 0), false},
+		{time.Date(2023, time.February, 29, 0, 0, 0, 0, time.UTC), false}, // normalized to March 1, 2023
+		{utc(2000, time.February, 29, 0, 0, 0), true},
+	}
+	for _, tt := range tests {
+		if got := timefy.With(tt.v).IsLeapDay(); got != tt.want {
+			t.Errorf("IsLeapDay(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestIsWithinTolerance(t *testing.T) {
+	ref := utc(2023, time.March, 15, 8, 0, 0)
+	tests := []struct {
+		offset    time.Duration
+		tolerance time.Duration
+		want      bool
+	}{
+		{0, 0, true},
+		{-10 * time.Second, 30 * time.Second, true},
+		{30 * time.Second, 30 * time.Second, true},
+		{31 * time.Second, 30 * time.Second, false},
+		{-2 * time.Minute, time.Minute, false},
+	}
+	for _, tt := range tests {
+		v := ref.Add(tt.offset)
+		if got := timefy.IsWithinToleranceOfRef(v, ref, tt.tolerance); got != tt.want {
+			t.Errorf("IsWithinToleranceOfRef(%v, %v) = %v, want %v", tt.offset, tt.tolerance, got, tt.want)
+		}
+	}
+	now := time.Now()
+	if !timefy.IsWithinToleranceOf(now.Add(-10*time.Second), 30*time.Second) || timefy.IsWithinToleranceOf(now.Add(-2*time.Minute), time.Minute) {
+		t.Error("IsWithinToleranceOf does not measure against the current time")
+	}
+	if !timefy.IsWithinTolerance(now.Add(30*time.Second)) || timefy.IsWithinTolerance(now.Add(2*time.Minute)) {
+		t.Error("IsWithinTolerance does not use a one-minute window")
+	}
+}
+
+func TestTimexJSON(t *testing.T) {
+	type event struct {
+		Name string        `json:"name"`
+		At   timefy.Timex  `json:"at"`
+		Ends *timefy.Timex `json:"ends"`
+	}
+	at := time.Date(2023, time.October, 25, 15, 4, 5, 123456789, time.UTC)
+	in := event{Name: "launch", At: *timefy.With(at), Ends: timefy.With(at.Add(time.Hour))}
+	data, err := json.Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"launch","at":"2023-10-25T15:04:05.123456789Z","ends":"2023-10-25T16:04:05.123456789Z"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+	var out event
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.At.Equal(at) || !out.Ends.Equal(at.Add(time.Hour)) || out.At.Config == nil {
+		t.Errorf("round trip = %v, %v; want %v, %v", out.At.Time, out.Ends.Time, at, at.Add(time.Hour))
+	}
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{`"2023-10-25T15:04:05Z"`, utc(2023, time.October, 25, 15, 4, 5)},
+		{`"2023-10-25 15:04:05"`, time.Date(2023, time.October, 25, 15, 4, 5, 0, time.Local)}, // falls back to TimeFormats
+	}
+	for _, tt := range tests {
+		var v timefy.Timex
+		if err := json.Unmarshal([]byte(tt.input), &v); err != nil || !v.Equal(tt.want) {
+			t.Errorf("json.Unmarshal(%s) = %v, %v; want %v", tt.input, v.Time, err, tt.want)
+		}
+	}
+	if err := json.Unmarshal([]byte(`"not a time"`), new(timefy.Timex)); err == nil {
+		t.Error("json.Unmarshal of an invalid time returned no error")
+	}
+
+	custom := (&timefy.Config{}).WithJSONFormat("2006-01-02")
+	data, err = json.Marshal(custom.With(at))
+	if err != nil || string(data) != `"2023-10-25"` {
+		t.Errorf("json.Marshal with a custom layout = %s, %v", data, err)
+	}
+}
+
+func TestDayOfYearToDate(t *testing.T) {
+	tests := []struct {
+		year, day int
+		want      time.Time
+		wantErr   bool
+	}{
+		{2024, 1, utc(2024, time.January, 1, 0, 0, 0), false},
+		{2024, 60, utc(2024, time.February, 29, 0, 0, 0), false},
+		{2023, 60, utc(2023, time.March, 1, 0, 0, 0), false},
+		{2024, 366, utc(2024, time.December, 31, 0, 0, 0), false},
+		{2023, 366, time.Time{}, true},
+		{2023, 0, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := timefy.DayOfYearToDate(tt.year, tt.day, time.UTC)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("DayOfYearToDate(%d, %d) = %v, %v; want %v, error %v", tt.year, tt.day, got, err, tt.want, tt.wantErr)
+		}
+		if err == nil && got.YearDay() != tt.day {
+			t.Errorf("DayOfYearToDate(%d, %d).YearDay() = %d", tt.year, tt.day, got.YearDay())
+		}
+	}
+	// In America/Santiago, clocks jumped from 00:00 to 01:00 on 2023-09-03, the 246th day of 2023.
+	santiago := loadLocation(t, "America/Santiago")
+	got, err := timefy.DayOfYearToDate(2023, 246, santiago)
+	if want := time.Date(2023, time.September, 3, 1, 0, 0, 0, santiago); err != nil || !got.Equal(want) || got.YearDay() != 246 {
+		t.Errorf("DayOfYearToDate(2023, 246, America/Santiago) = %v, %v; want %v", got, err, want)
+	}
+}
+
+func TestUntilEndOfWeek(t *testing.T) {
+	v := utc(2023, time.October, 25, 12, 0, 0) // Wednesday
+	tests := []struct {
+		weekStart time.Weekday
+		want      time.Duration
+	}{
+		{time.Sunday, 3*24*time.Hour + 12*time.Hour - time.Nanosecond},
+		{time.Monday, 4*24*time.Hour + 12*time.Hour - time.Nanosecond},
+		{time.Thursday, 12*time.Hour - time.Nanosecond},
+	}
+	for _, tt := range tests {
+		config := &timefy.Config{WeekStartDay: tt.weekStart}
+		if got := config.With(v).UntilEndOfWeek(); got != tt.want {
+			t.Errorf("UntilEndOfWeek() with week start %v = %v, want %v", tt.weekStart, got, tt.want)
+		}
+	}
+}
+
+func TestDurationSinceUntil(t *testing.T) {
+	for _, offset := range []time.Duration{0, 90 * time.Second, -2 * time.Hour} {
+		v := time.Now().Add(-offset)
+		if got := timefy.DurationSince(v); got < offset || got > offset+time.Minute {
+			t.Errorf("DurationSince(now - %v) = %v", offset, got)
+		}
+		v = time.Now().Add(offset)
+		if got := timefy.DurationUntil(v); got > offset || got < offset-time.Minute {
+			t.Errorf("DurationUntil(now + %v) = %v", offset, got)
+		}
+	}
+}
+
+// hours returns the range between the given hours of 2023-10-25 in UTC.
+func hours(from, to float64) timefy.Range {
+	day := utc(2023, time.October, 25, 0, 0, 0)
+	return timefy.Range{
+		Start: day.Add(time.Duration(from * float64(time.Hour))),
+		End:   day.Add(time.Duration(to * float64(time.Hour))),
+	}
+}
+
+func TestFindOverlaps(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []timefy.Range
+		want   [][2]int
+	}{
+		{"empty", nil, nil},
+		{"back to back", []timefy.Range{hours(9, 10), hours(10, 11)}, nil},
+		{"unsorted input", []timefy.Range{hours(10.5, 12), hours(9, 10), hours(10, 11)}, [][2]int{{0, 2}}},
+		{"nested", []timefy.Range{hours(8, 18), hours(9, 10), hours(12, 13)}, [][2]int{{0, 1}, {0, 2}}},
+		{"identical", []timefy.Range{hours(9, 10), hours(9, 10)}, [][2]int{{0, 1}}},
+	}
+	for _, tt := range tests {
+		got := timefy.FindOverlaps(tt.ranges)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) || len(got) != len(tt.want) {
+			t.Errorf("%s: FindOverlaps() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsWeekendWeekday(t *testing.T) {
+	tests := []struct {
+		v       time.Time
+		weekend bool
+	}{
+		{utc(2023, time.October, 27, 23, 59, 59), false}, // Friday
+		{utc(2023, time.October, 28, 0, 0, 0), true},     // Saturday
+		{utc(2023, time.October, 29, 12, 0, 0), true},    // Sunday
+		{utc(2023, time.October, 30, 0, 0, 0), false},    // Monday
+	}
+	for _, tt := range tests {
+		if got := timefy.IsWeekend(tt.v); got != tt.weekend {
+			t.Errorf("IsWeekend(%v) = %v, want %v", tt.v, got, tt.weekend)
+		}
+		if got := timefy.IsWeekday(tt.v); got == tt.weekend {
+			t.Errorf("IsWeekday(%v) = %v, want %v", tt.v, got, !tt.weekend)
+		}
+	}
+	// Saturday 01:00 in Tokyo is still Friday in UTC: the location of the value decides.
+	tokyo := time.Date(2023, time.October, 28, 1, 0, 0, 0, loadLocation(t, "Asia/Tokyo"))
+	if !timefy.IsWeekend(tokyo) || timefy.IsWeekend(tokyo.UTC()) {
+		t.Errorf("IsWeekend does not use the location of the value")
+	}
+}
+
+func TestMergeRanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []timefy.Range
+		want   []timefy.Range
+	}{
+		{"empty", nil, nil},
+		{"touching", []timefy.Range{hours(10, 11), hours(9, 10)}, []timefy.Range{hours(9, 11)}},
+		{"disjoint", []timefy.Range{hours(14, 15), hours(9, 10)}, []timefy.Range{hours(9, 10), hours(14, 15)}},
+		{"contained", []timefy.Range{hours(8, 18), hours(9, 10), hours(17, 19)}, []timefy.Range{hours(8, 19)}},
+	}
+	for _, tt := range tests {
+		input := append([]timefy.Range(nil), tt.ranges...)
+		got := timefy.MergeRanges(tt.ranges)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) || len(got) != len(tt.want) {
+			t.Errorf("%s: MergeRanges() = %v, want %v", tt.name, got, tt.want)
+		}
+		if fmt.Sprint(input) != fmt.Sprint(tt.ranges) {
+			t.Errorf("%s: MergeRanges() modified its input", tt.name)
+		}
+	}
+}
+
+func TestWeekdayOf(t *testing.T) {
+	wednesday := utc(2024, time.October, 23, 15, 0, 0)
+	sunday := utc(2024, time.October, 27, 23, 0, 0)
+	tests := []struct {
+		name string
+		fn   func(time.Time) time.Time
+		day  int
+	}{
+		{"MondayOf", timefy.MondayOf, 21},
+		{"TuesdayOf", timefy.TuesdayOf, 22},
+		{"WednesdayOf", timefy.WednesdayOf, 23},
+		{"ThursdayOf", timefy.ThursdayOf, 24},
+		{"FridayOf", timefy.FridayOf, 25},
+		{"SaturdayOf", timefy.SaturdayOf, 26},
+		{"SundayOf", timefy.SundayOf, 27},
+	}
+	defer func(start time.Weekday) { timefy.WeekStartDay = start }(timefy.WeekStartDay)
+	timefy.WeekStartDay = time.Wednesday // must not affect the result
+	for _, tt := range tests {
+		want := utc(2024, time.October, tt.day, 0, 0, 0)
+		for _, v := range []time.Time{wednesday, sunday} {
+			if got := tt.fn(v); !got.Equal(want) {
+				t.Errorf("%s(%v) = %v, want %v", tt.name, v, got, want)
+			}
+		}
+	}
+	// In America/Santiago, clocks jumped from 00:00 to 01:00 on Sunday 2023-09-03.
+	santiago := loadLocation(t, "America/Santiago")
+	v := time.Date(2023, time.September, 3, 10, 0, 0, 0, santiago)
+	skipped := []struct {
+		name string
+		fn   func(time.Time) time.Time
+		want time.Time
+	}{
+		{"MondayOf", timefy.MondayOf, time.Date(2023, time.August, 28, 0, 0, 0, 0, santiago)},
+		{"SaturdayOf", timefy.SaturdayOf, time.Date(2023, time.September, 2, 0, 0, 0, 0, santiago)},
+		{"SundayOf", timefy.SundayOf, time.Date(2023, time.September, 3, 1, 0, 0, 0, santiago)},
+	}
+	for _, tt := range skipped {
+		if got := tt.fn(v); !got.Equal(tt.want) {
+			t.Errorf("%s(%v) = %v, want %v", tt.name, v, got, tt.want)
+		}
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	tests := []struct {
+		year  int
+		month time.Month
+		want  int
+	}{
+		{2023, time.January, 31},
+		{2023, time.February, 28},
+		{2024, time.February, 29},
+		{1900, time.February, 28},
+		{2000, time.February, 29},
+		{2023, time.April, 30},
+		{2023, time.December, 31},
+	}
+	for _, tt := range tests {
+		if got := timefy.DaysInMonth(tt.year, tt.month); got != tt.want {
+			t.Errorf("DaysInMonth(%d, %v) = %d, want %d", tt.year, tt.month, got, tt.want)
+		}
+		if got := timefy.DaysInMonthN(time.Date(tt.year, tt.month, 15, 0, 0, 0, 0, time.UTC)); got != tt.want {
+			t.Errorf("DaysInMonthN(%d-%02d) = %d, want %d", tt.year, tt.month, got, tt.want)
+		}
+	}
+}
+
+func TestFreeSlots(t *testing.T) {
+	window := hours(9, 17)
+	tests := []struct {
+		name string
+		busy []timefy.Range
+		min  time.Duration
+		want []timefy.Range
+	}{
+		{"no busy", nil, 0, []timefy.Range{hours(9, 17)}},
+		{"one block", []timefy.Range{hours(10, 12)}, 0, []timefy.Range{hours(9, 10), hours(12, 17)}},
+		{"clipped to window", []timefy.Range{hours(7, 10), hours(16, 18)}, 0, []timefy.Range{hours(10, 16)}},
+		{"overlapping busy", []timefy.Range{hours(11, 13), hours(10, 12)}, 0, []timefy.Range{hours(9, 10), hours(13, 17)}},
+		{"short gaps dropped", []timefy.Range{hours(9.5, 12), hours(12.25, 16)}, time.Hour, []timefy.Range{hours(16, 17)}},
+		{"fully busy", []timefy.Range{hours(8, 18)}, 0, nil},
+	}
+	for _, tt := range tests {
+		got := timefy.FreeSlots(window, tt.busy, tt.min)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) || len(got) != len(tt.want) {
+			t.Errorf("%s: FreeSlots() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFiscalYear(t *testing.T) {
+	tests := []struct {
+		v          time.Time
+		startMonth time.Month
+		begin, end time.Time
+	}{
+		{utc(2024, time.March, 15, 0, 0, 0), time.April, utc(2023, time.April, 1, 0, 0, 0), utc(2024, time.April, 1, 0, 0, 0)},
+		{utc(2024, time.April, 1, 0, 0, 0), time.April, utc(2024, time.April, 1, 0, 0, 0), utc(2025, time.April, 1, 0, 0, 0)},
+		{utc(2024, time.December, 31, 0, 0, 0), time.October, utc(2024, time.October, 1, 0, 0, 0), utc(2025, time.October, 1, 0, 0, 0)},
+		{utc(2024, time.June, 30, 0, 0, 0), time.January, utc(2024, time.January, 1, 0, 0, 0), utc(2025, time.January, 1, 0, 0, 0)},
+		{utc(2024, time.June, 30, 0, 0, 0), 13, utc(2024, time.January, 1, 0, 0, 0), utc(2025, time.January, 1, 0, 0, 0)},
+	}
+	for _, tt := range tests {
+		tx := timefy.With(tt.v)
+		if got := tx.BeginningOfFiscalYear(tt.startMonth); !got.Equal(tt.begin) {
+			t.Errorf("BeginningOfFiscalYear(%v) of %v = %v, want %v", tt.startMonth, tt.v, got, tt.begin)
+		}
+		if got := tx.EndOfFiscalYear(tt.startMonth); !got.Equal(tt.end.Add(-time.Nanosecond)) {
+			t.Errorf("EndOfFiscalYear(%v) of %v = %v, want %v", tt.startMonth, tt.v, got, tt.end.Add(-time.Nanosecond))
+		}
+	}
+}
+
+func TestTimeAtOffsetIntoDay(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	tests := []struct {
+		date   time.Time
+		offset time.Duration
+		want   time.Time
+	}{
+		{utc(2023, time.October, 25, 18, 0, 0), 9*time.Hour + 30*time.Minute, utc(2023, time.October, 25, 9, 30, 0)},
+		{utc(2023, time.October, 25, 18, 0, 0), 26 * time.Hour, utc(2023, time.October, 26, 2, 0, 0)},
+		{utc(2023, time.October, 25, 18, 0, 0), -time.Hour, utc(2023, time.October, 24, 23, 0, 0)},
+		// Elapsed time, not wall clock: 9h after midnight on a spring-forward day reads 10:00.
+		{time.Date(2023, time.March, 12, 15, 0, 0, 0, ny), 9 * time.Hour, time.Date(2023, time.March, 12, 10, 0, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		if got := timefy.TimeAtOffsetIntoDay(tt.date, tt.offset); !got.Equal(tt.want) {
+			t.Errorf("TimeAtOffsetIntoDay(%v, %v) = %v, want %v", tt.date, tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestISOWeek(t *testing.T) {
+	tests := []struct {
+		v          time.Time
+		year, week int
+	}{
+		{utc(2023, time.January, 1, 0, 0, 0), 2022, 52},
+		{utc(2023, time.January, 2, 0, 0, 0), 2023, 1},
+		{utc(2018, time.December, 31, 0, 0, 0), 2019, 1},
+		{utc(2020, time.December, 31, 0, 0, 0), 2020, 53},
+		{utc(2021, time.January, 3, 0, 0, 0), 2020, 53},
+	}
+	for _, tt := range tests {
+		if year, week := timefy.ISOWeek(tt.v); year != tt.year || week != tt.week {
+			t.Errorf("ISOWeek(%v) = %d, %d; want %d, %d", tt.v, year, week, tt.year, tt.week)
+		}
+		config := &timefy.Config{WeekStartDay: time.Sunday}
+		if year, week := config.With(tt.v).WeekOfYear(); year != tt.year || week != tt.week {
+			t.Errorf("WeekOfYear(%v) with a Sunday week start = %d, %d; want %d, %d", tt.v, year, week, tt.year, tt.week)
+		}
+	}
+}
+
+func TestAgeBucket(t *testing.T) {
+	bounds := []int{18, 25, 35, 50}
+	birth := utc(2000, time.June, 15, 0, 0, 0)
+	leap := utc(2004, time.February, 29, 0, 0, 0)
+	tests := []struct {
+		birth, at time.Time
+		want      int
+	}{
+		{birth, utc(2010, time.January, 1, 0, 0, 0), 0},
+		{birth, utc(2018, time.June, 14, 23, 59, 59), 0},
+		{birth, utc(2018, time.June, 15, 0, 0, 0), 1},
+		{birth, utc(2025, time.June, 15, 0, 0, 0), 2},
+		{birth, utc(2050, time.June, 15, 0, 0, 0), 4},
+		{leap, utc(2022, time.February, 27, 0, 0, 0), 0},
+		{leap, utc(2022, time.February, 28, 0, 0, 0), 1}, // leap day birthdays fall on Feb 28th
+	}
+	for _, tt := range tests {
+		if got := timefy.AgeBucket(tt.birth, tt.at, bounds); got != tt.want {
+			t.Errorf("AgeBucket(%v, %v) = %d, want %d", tt.birth, tt.at, got, tt.want)
+		}
+	}
+	if got := timefy.AgeBucket(birth, utc(2060, time.January, 1, 0, 0, 0), nil); got != 0 {
+		t.Errorf("AgeBucket() without bounds = %d, want 0", got)
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	tests := []struct {
+		v              time.Time
+		hour, min, sec int
+		want           time.Time
+	}{
+		{utc(2023, time.October, 25, 15, 0, 0), 14, 30, 0, utc(2023, time.October, 26, 14, 30, 0)},
+		{utc(2023, time.October, 25, 14, 0, 0), 14, 30, 0, utc(2023, time.October, 25, 14, 30, 0)},
+		{utc(2023, time.October, 25, 14, 30, 0), 14, 30, 0, utc(2023, time.October, 26, 14, 30, 0)}, // strictly after
+		{utc(2023, time.December, 31, 23, 0, 0), 0, 0, 0, utc(2024, time.January, 1, 0, 0, 0)},
+		// 02:30 is skipped on a spring-forward day and resolves to 03:30 EDT.
+		{time.Date(2023, time.March, 12, 0, 0, 0, 0, ny), 2, 30, 0, time.Date(2023, time.March, 12, 7, 30, 0, 0, time.UTC)},
+		// 01:30 is repeated on a fall-back day and resolves to the earlier instant, 01:30 EDT.
+		{time.Date(2023, time.November, 5, 0, 0, 0, 0, ny), 1, 30, 0, time.Date(2023, time.November, 5, 5, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := timefy.NextOccurrence(tt.v, tt.hour, tt.min, tt.sec); !got.Equal(tt.want) {
+			t.Errorf("NextOccurrence(%v, %02d:%02d:%02d) = %v, want %v", tt.v, tt.hour, tt.min, tt.sec, got, tt.want)
+		}
+	}
+}
+
+func TestSnapRangeToDays(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	tests := []struct {
+		name string
+		r    timefy.Range
+		loc  *time.Location
+		want timefy.Range
+	}{
+		{"same day", hours(10, 14), time.UTC, timefy.Range{
+			Start: utc(2023, time.October, 25, 0, 0, 0),
+			End:   utc(2023, time.October, 26, 0, 0, 0).Add(-time.Nanosecond),
+		}},
+		{"spans days", hours(22, 26), nil, timefy.Range{
+			Start: utc(2023, time.October, 25, 0, 0, 0),
+			End:   utc(2023, time.October, 27, 0, 0, 0).Add(-time.Nanosecond),
+		}},
+		{"other location", hours(10, 14), tokyo, timefy.Range{
+			Start: time.Date(2023, time.October, 25, 0, 0, 0, 0, tokyo),
+			End:   time.Date(2023, time.October, 26, 0, 0, 0, 0, tokyo).Add(-time.Nanosecond),
+		}},
+	}
+	for _, tt := range tests {
+		got := timefy.SnapRangeToDays(tt.r, tt.loc)
+		if !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+			t.Errorf("%s: SnapRangeToDays() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// tickClock is a Clock advancing by step on every reading.
+type tickClock struct {
+	current time.Time
+	step    time.Duration
+}
+
+func (c *tickClock) Now() time.Time {
+	v := c.current
+	c.current = c.current.Add(c.step)
+	return v
+}
+
+func TestFrozenClock(t *testing.T) {
+	ref := utc(2023, time.October, 25, 15, 4, 5)
+	freezeClock(t, ref)
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"BeginningOfDay", timefy.BeginningOfDay(), utc(2023, time.October, 25, 0, 0, 0)},
+		{"BeginningOfHour", timefy.BeginningOfHour(), utc(2023, time.October, 25, 15, 0, 0)},
+		{"BeginningOfMonth", timefy.BeginningOfMonth(), utc(2023, time.October, 1, 0, 0, 0)},
+		{"EndOfDay", timefy.EndOfDay(), utc(2023, time.October, 26, 0, 0, 0).Add(-time.Nanosecond)},
+	}
+	for _, tt := range tests {
+		if !tt.got.Equal(tt.want) {
+			t.Errorf("%s() = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	for _, offset := range []time.Duration{0, 10 * time.Second, 2 * time.Hour, 3 * 24 * time.Hour} {
+		if got := timefy.DurationSince(ref.Add(-offset)); got != offset {
+			t.Errorf("DurationSince(ref - %v) = %v, want %v", offset, got, offset)
+		}
+		if got := timefy.DurationUntil(ref.Add(offset)); got != offset {
+			t.Errorf("DurationUntil(ref + %v) = %v, want %v", offset, got, offset)
+		}
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	ref := utc(2023, time.October, 25, 15, 0, 0)
+	timefy.SetClock(&tickClock{current: ref, step: time.Minute})
+	t.Cleanup(func() { timefy.SetClock(nil) })
+	if got := timefy.DurationSince(ref); got != 0 {
+		t.Errorf("first reading = %v, want 0", got)
+	}
+	if got := timefy.DurationSince(ref); got != time.Minute {
+		t.Errorf("second reading = %v, want 1m0s", got)
+	}
+	timefy.SetClock(nil)
+	if got := timefy.DurationSince(time.Now()); got < 0 || got > time.Second {
+		t.Errorf("SetClock(nil) did not restore the wall clock: %v", got)
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	friday := utc(2023, time.December, 22, 9, 0, 0)
+	christmas := utc(2023, time.December, 25, 0, 0, 0)
+	boxing := utc(2023, time.December, 26, 12, 0, 0) // time of day is ignored
+	tests := []struct {
+		v        time.Time
+		days     int
+		holidays []time.Time
+		want     time.Time
+	}{
+		{friday, 0, nil, friday},
+		{friday, 1, nil, utc(2023, time.December, 25, 9, 0, 0)},
+		{friday, 5, nil, utc(2023, time.December, 29, 9, 0, 0)},
+		{friday, -5, nil, utc(2023, time.December, 15, 9, 0, 0)},
+		{utc(2023, time.December, 23, 9, 0, 0), 1, nil, utc(2023, time.December, 25, 9, 0, 0)}, // from Saturday
+		{friday, 1, []time.Time{christmas}, utc(2023, time.December, 26, 9, 0, 0)},
+		{friday, 1, []time.Time{christmas, boxing}, utc(2023, time.December, 27, 9, 0, 0)},
+		{utc(2023, time.December, 27, 9, 0, 0), -1, []time.Time{christmas, boxing}, friday},
+	}
+	for _, tt := range tests {
+		got := timefy.AddBusinessDaysWithHolidays(tt.v, tt.days, tt.holidays)
+		if !got.Equal(tt.want) {
+			t.Errorf("AddBusinessDaysWithHolidays(%v, %d, %v) = %v, want %v", tt.v, tt.days, tt.holidays, got, tt.want)
+		}
+		if tt.holidays == nil && !timefy.AddBusinessDays(tt.v, tt.days).Equal(tt.want) {
+			t.Errorf("AddBusinessDays(%v, %d) = %v, want %v", tt.v, tt.days, timefy.AddBusinessDays(tt.v, tt.days), tt.want)
+		}
+	}
+}
+
+func TestIsBusinessHoursNow(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	open, close := timefy.ClockTime{Hour: 9}, timefy.ClockTime{Hour: 17, Minute: 30}
+	tests := []struct {
+		now  time.Time
+		loc  *time.Location
+		want bool
+	}{
+		{utc(2023, time.October, 25, 8, 59, 59), time.UTC, false},
+		{utc(2023, time.October, 25, 9, 0, 0), time.UTC, true},
+		{utc(2023, time.October, 25, 17, 29, 59), time.UTC, true},
+		{utc(2023, time.October, 25, 17, 30, 0), time.UTC, false},
+		{utc(2023, time.October, 28, 12, 0, 0), time.UTC, false}, // Saturday
+		{utc(2023, time.October, 25, 1, 0, 0), tokyo, true},      // 10:00 in Tokyo
+		{utc(2023, time.October, 27, 23, 0, 0), tokyo, false},    // Saturday 08:00 in Tokyo
+	}
+	for _, tt := range tests {
+		freezeClock(t, tt.now)
+		config := &timefy.Config{TimeLocation: tt.loc}
+		if got := config.IsBusinessHoursNow(open, close); got != tt.want {
+			t.Errorf("IsBusinessHoursNow() at %v in %v = %v, want %v", tt.now, tt.loc, got, tt.want)
+		}
+	}
+}
+
+func TestBetweenTime(t *testing.T) {
+	start, end := utc(2023, time.October, 20, 0, 0, 0), utc(2023, time.October, 30, 0, 0, 0)
+	tests := []struct {
+		v    time.Time
+		want bool
+	}{
+		{start, true},
+		{end, true},
+		{utc(2023, time.October, 25, 0, 0, 0), true},
+		{start.Add(-time.Nanosecond), false},
+		{end.Add(time.Nanosecond), false},
+	}
+	for _, tt := range tests {
+		if got := timefy.BetweenTime(tt.v, start, end); got != tt.want {
+			t.Errorf("BetweenTime(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+		if got := timefy.With(tt.v).BetweenTime(end, start); got != tt.want {
+			t.Errorf("BetweenTime(%v) with swapped bounds = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestLastOccurrence(t *testing.T) {
+	ref := utc(2023, time.October, 25, 15, 0, 0) // Wednesday
+	tests := []struct {
+		weekday time.Weekday
+		clock   timefy.ClockTime
+		want    time.Time
+	}{
+		{time.Wednesday, timefy.ClockTime{Hour: 9}, utc(2023, time.October, 25, 9, 0, 0)},
+		{time.Wednesday, timefy.ClockTime{Hour: 15}, ref},
+		{time.Wednesday, timefy.ClockTime{Hour: 18}, utc(2023, time.October, 18, 18, 0, 0)},
+		{time.Monday, timefy.ClockTime{Hour: 9}, utc(2023, time.October, 23, 9, 0, 0)},
+		{time.Thursday, timefy.ClockTime{Hour: 9, Minute: 30}, utc(2023, time.October, 19, 9, 30, 0)},
+	}
+	for _, tt := range tests {
+		if got := timefy.LastOccurrence(ref, tt.weekday, tt.clock); !got.Equal(tt.want) {
+			t.Errorf("LastOccurrence(%v, %+v) = %v, want %v", tt.weekday, tt.clock, got, tt.want)
+		}
+	}
+}
+
+func TestTimeAgoShort(t *testing.T) {
+	ref := utc(2023, time.October, 25, 15, 0, 0)
+	freezeClock(t, ref)
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{0, "now"},
+		{59 * time.Second, "now"},
+		{5 * time.Minute, "5m"},
+		{90 * time.Minute, "1h"},
+		{3 * 24 * time.Hour, "3d"},
+		{10 * 24 * time.Hour, "1w"},
+		{45 * 24 * time.Hour, "1mo"},
+		{800 * 24 * time.Hour, "2y"},
+	}
+	for _, tt := range tests {
+		if got := timefy.With(ref.Add(-tt.offset)).TimeAgoShort(); got != tt.want {
+			t.Errorf("TimeAgoShort(-%v) = %q, want %q", tt.offset, got, tt.want)
+		}
+		want := tt.want
+		if want != "now" {
+			want = "in " + want
+		}
+		if got := timefy.With(ref.Add(tt.offset)).TimeUntilShort(); got != want {
+			t.Errorf("TimeUntilShort(%v) = %q, want %q", tt.offset, got, want)
+		}
+	}
+	if got := timefy.With(ref.Add(time.Hour)).TimeAgoShort(); got != "now" {
+		t.Errorf("TimeAgoShort() of a future time = %q, want \"now\"", got)
+	}
+}
+
+func TestBucketID(t *testing.T) {
+	floorDiv := func(a, b int64) int64 {
+		q := a / b
+		if a%b < 0 {
+			q--
+		}
+		return q
+	}
+	tests := []struct {
+		v    time.Time
+		size time.Duration
+		want string
+	}{
+		{utc(2023, time.October, 25, 15, 4, 5), time.Hour, strconv.FormatInt(utc(2023, time.October, 25, 15, 0, 0).Unix()/3600, 36)},
+		{utc(1970, time.January, 1, 0, 0, 0), time.Hour, "0"},
+		{utc(1969, time.December, 31, 23, 59, 59), time.Hour, "-1"},
+		{utc(1969, time.December, 31, 23, 59, 59), 0, strconv.FormatInt(-1e9, 36)},
+		// Outside 1678-2262, UnixNano overflows; the index is still exact.
+		{utc(3000, time.January, 1, 12, 30, 0), time.Hour, strconv.FormatInt(floorDiv(utc(3000, time.January, 1, 12, 0, 0).Unix(), 3600), 36)},
+		{utc(1000, time.January, 1, 12, 30, 0), 24 * time.Hour, strconv.FormatInt(floorDiv(utc(1000, time.January, 1, 0, 0, 0).Unix(), 86400), 36)},
+	}
+	for _, tt := range tests {
+		if got := timefy.BucketID(tt.v, tt.size); got != tt.want {
+			t.Errorf("BucketID(%v, %v) = %q, want %q", tt.v, tt.size, got, tt.want)
+		}
+	}
+	v := utc(2023, time.October, 25, 15, 4, 5)
+	tokyo := v.In(loadLocation(t, "Asia/Tokyo"))
+	if timefy.BucketID(v, time.Minute) != timefy.BucketID(tokyo, time.Minute) {
+		t.Error("BucketID depends on the location of the value")
+	}
+	if timefy.BucketID(v, time.Minute) == timefy.BucketID(v.Add(time.Minute), time.Minute) {
+		t.Error("BucketID does not distinguish adjacent windows")
+	}
+	for _, size := range []time.Duration{time.Nanosecond, 7 * time.Millisecond, 90 * time.Minute} {
+		want := strconv.FormatInt(floorDiv(v.UnixNano(), int64(size)), 36)
+		if got := timefy.BucketID(v, size); got != want {
+			t.Errorf("BucketID(%v) = %q, want %q", size, got, want)
+		}
+	}
+	// Far from 1970, small sizes give indices beyond the int64 range.
+	for _, v := range []time.Time{time.Date(9999, time.December, 31, 23, 59, 59, 999999999, time.UTC), time.Date(1, time.January, 1, 0, 0, 0, 1, time.UTC)} {
+		for _, size := range []time.Duration{time.Nanosecond, 3 * time.Nanosecond, 7 * time.Millisecond, time.Hour, math.MaxInt64} {
+			ns := new(big.Int).Mul(big.NewInt(v.Unix()), big.NewInt(int64(time.Second)))
+			ns.Add(ns, big.NewInt(int64(v.Nanosecond())))
+			want := ns.Div(ns, big.NewInt(int64(size))).Text(36)
+			if got := timefy.BucketID(v, size); got != want {
+				t.Errorf("BucketID(%v, %v) = %q, want %q", v, size, got, want)
+			}
+		}
+	}
+}
+
+func TestBusinessDiff(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	open, close := timefy.ClockTime{Hour: 9}, timefy.ClockTime{Hour: 17}
+	friday := utc(2023, time.October, 27, 15, 0, 0)
+	tests := []struct {
+		name        string
+		a, b        time.Time
+		open, close timefy.ClockTime
+		loc         *time.Location
+		days        int
+		rest        time.Duration
+	}{
+		{"over a weekend", friday, utc(2023, time.October, 31, 11, 0, 0), open, close, time.UTC, 1, 4 * time.Hour},
+		{"reversed", utc(2023, time.October, 31, 11, 0, 0), friday, open, close, time.UTC, -1, -4 * time.Hour},
+		{"weekend only", utc(2023, time.October, 28, 8, 0, 0), utc(2023, time.October, 29, 20, 0, 0), open, close, time.UTC, 0, 0},
+		{"outside hours", utc(2023, time.October, 25, 17, 0, 0), utc(2023, time.October, 26, 9, 0, 0), open, close, time.UTC, 0, 0},
+		{"full week", utc(2023, time.October, 23, 0, 0, 0), utc(2023, time.October, 30, 0, 0, 0), open, close, time.UTC, 5, 0},
+		{"empty window", friday, utc(2023, time.October, 31, 11, 0, 0), close, open, time.UTC, 0, 0},
+		// 13:00-21:00 UTC are the New York business hours (EDT, UTC-4).
+		{"other location", utc(2023, time.October, 25, 12, 0, 0), utc(2023, time.October, 25, 15, 30, 0), open, close, ny, 0, 2*time.Hour + 30*time.Minute},
+	}
+	for _, tt := range tests {
+		days, rest := timefy.BusinessDiff(tt.a, tt.b, tt.open, tt.close, tt.loc)
+		if days != tt.days || rest != tt.rest {
+			t.Errorf("%s: BusinessDiff() = %d, %v; want %d, %v", tt.name, days, rest, tt.days, tt.rest)
+		}
+	}
+}
+
+// frenchLocale is a test locale translating the relative time labels.
+var frenchLocale = timefy.Locale{
+	JustNow: "à l'instant",
+	Past:    "il y a %s",
+	Future:  "dans %s",
+	Minute:  timefy.Plural{One: "%d minute", Other: "%d minutes"},
+	Hour:    timefy.Plural{One: "%d heure", Other: "%d heures"},
+	Day:     timefy.Plural{One: "%d jour", Other: "%d jours"},
+	Week:    timefy.Plural{One: "%d semaine", Other: "%d semaines"},
+	Month:   timefy.Plural{One: "%d mois", Other: "%d mois"},
+	Year:    timefy.Plural{One: "%d an", Other: "%d ans"},
+}
+
+func TestTimeAgoLocale(t *testing.T) {
+	ref := utc(2023, time.October, 25, 15, 0, 0)
+	freezeClock(t, ref)
+	tests := []struct {
+		offset  time.Duration
+		english string
+		french  string
+	}{
+		{-10 * time.Second, "just now", "à l'instant"},
+		{-time.Minute, "1 minute ago", "il y a 1 minute"},
+		{-3 * time.Hour, "3 hours ago", "il y a 3 heures"},
+		{-24 * time.Hour, "1 day ago", "il y a 1 jour"},
+		{-14 * 24 * time.Hour, "2 weeks ago", "il y a 2 semaines"},
+		// Future times use the Future template instead of collapsing to JustNow.
+		{2 * time.Hour, "in 2 hours", "dans 2 heures"},
+		{3 * 24 * time.Hour, "in 3 days", "dans 3 jours"},
+	}
+	for _, tt := range tests {
+		tx := timefy.With(ref.Add(tt.offset))
+		if got := tx.TimeAgo(); got != tt.english {
+			t.Errorf("TimeAgo(%v) = %q, want %q", tt.offset, got, tt.english)
+		}
+		if got := tx.TimeAgoLocale(frenchLocale); got != tt.french {
+			t.Errorf("TimeAgoLocale(%v) = %q, want %q", tt.offset, got, tt.french)
+		}
+	}
+	if got := timefy.With(ref.Add(5 * time.Minute)).TimeUntilLocale(frenchLocale); got != "dans 5 minutes" {
+		t.Errorf("TimeUntilLocale(5m) = %q, want %q", got, "dans 5 minutes")
+	}
+}
+
+func TestDiffParts(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	tests := []struct {
+		from, to time.Time
+		want     [6]int
+	}{
+		{utc(2020, time.January, 31, 0, 0, 0), utc(2020, time.March, 1, 0, 0, 0), [6]int{0, 1, 1, 0, 0, 0}},
+		{utc(2020, time.January, 31, 0, 0, 0), utc(2020, time.February, 29, 0, 0, 0), [6]int{0, 1, 0, 0, 0, 0}},
+		{utc(2020, time.March, 1, 0, 0, 0), utc(2020, time.January, 31, 0, 0, 0), [6]int{0, -1, -1, 0, 0, 0}},
+		{utc(2020, time.May, 20, 8, 0, 0), utc(2023, time.July, 25, 10, 30, 15), [6]int{3, 2, 5, 2, 30, 15}},
+		{utc(2023, time.October, 25, 23, 0, 0), utc(2023, time.October, 26, 1, 0, 0), [6]int{0, 0, 0, 2, 0, 0}},
+		{utc(2023, time.October, 25, 0, 0, 0), utc(2023, time.October, 25, 0, 0, 0), [6]int{}},
+		// A calendar day across a DST transition is 23 hours long, not 24.
+		{time.Date(2023, time.March, 11, 12, 0, 0, 0, ny), time.Date(2023, time.March, 12, 12, 0, 0, 0, ny), [6]int{0, 0, 1, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		y, mo, d, h, mi, s := timefy.DiffParts(tt.from, tt.to)
+		if got := [6]int{y, mo, d, h, mi, s}; got != tt.want {
+			t.Errorf("DiffParts(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestFormatICS(t *testing.T) {
+	hcm := loadLocation(t, "Asia/Ho_Chi_Minh")
+	tests := []struct {
+		v          time.Time
+		utc, local string
+		wantTZID   string
+	}{
+		{time.Date(2023, time.August, 15, 20, 45, 30, 0, hcm), "20230815T134530Z", "20230815T204530", "Asia/Ho_Chi_Minh"},
+		{time.Date(2023, time.August, 15, 2, 0, 0, 999999999, hcm), "20230814T190000Z", "20230815T020000", "Asia/Ho_Chi_Minh"},
+		{utc(2024, time.February, 29, 0, 0, 0), "20240229T000000Z", "20240229T000000", "UTC"},
+	}
+	for _, tt := range tests {
+		if got := timefy.FormatICS(tt.v); got != tt.utc {
+			t.Errorf("FormatICS(%v) = %q, want %q", tt.v, got, tt.utc)
+		}
+		if value, tzid := timefy.FormatICSLocal(tt.v); value != tt.local || tzid != tt.wantTZID {
+			t.Errorf("FormatICSLocal(%v) = %q, %q; want %q, %q", tt.v, value, tzid, tt.local, tt.wantTZID)
+		}
+	}
+}
+
+func TestAge(t *testing.T) {
+	leap := utc(2000, time.February, 29, 0, 0, 0)
+	birth := utc(1990, time.May, 20, 0, 0, 0)
+	tests := []struct {
+		birth, at time.Time
+		want      int
+	}{
+		{birth, utc(2023, time.May, 19, 23, 59, 59), 32},
+		{birth, utc(2023, time.May, 20, 0, 0, 0), 33},
+		{birth, birth, 0},
+		{leap, utc(2021, time.February, 27, 0, 0, 0), 20},
+		{leap, utc(2021, time.February, 28, 0, 0, 0), 21},
+		{leap, utc(2024, time.February, 28, 0, 0, 0), 23},
+		{leap, utc(2024, time.February, 29, 0, 0, 0), 24},
+	}
+	for _, tt := range tests {
+		if got := timefy.AgeAt(tt.birth, tt.at); got != tt.want {
+			t.Errorf("AgeAt(%v, %v) = %d, want %d", tt.birth, tt.at, got, tt.want)
+		}
+	}
+	freezeClock(t, utc(2023, time.May, 20, 0, 0, 0))
+	if got := timefy.Age(birth); got != 33 {
+		t.Errorf("Age(%v) = %d, want 33", birth, got)
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"20230815T134530Z", utc(2023, time.August, 15, 13, 45, 30), false},
+		{" 20230815T134530Z\n", utc(2023, time.August, 15, 13, 45, 30), false},
+		{"20230815T204530", time.Date(2023, time.August, 15, 20, 45, 30, 0, time.Local), false},
+		{"2023-08-15", time.Time{}, true},
+		{"20231315T000000Z", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := timefy.ParseICS(tt.input)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("ParseICS(%q) = %v, %v; want %v, error %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+	v := time.Date(2023, time.August, 15, 20, 45, 30, 0, loadLocation(t, "Asia/Tokyo"))
+	if got, err := timefy.ParseICS(timefy.FormatICS(v)); err != nil || !got.Equal(v) {
+		t.Errorf("ParseICS(FormatICS(%v)) = %v, %v", v, got, err)
+	}
+}
+
+func TestFromUnix(t *testing.T) {
+	want := time.Date(2023, time.August, 15, 13, 45, 30, 0, time.UTC)
+	if got := timefy.FromUnix(1692107130); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("FromUnix() = %v, want %v", got, want)
+	}
+	if got := timefy.FromUnixMilli(1692107130123); !got.Equal(want.Add(123 * time.Millisecond)) {
+		t.Errorf("FromUnixMilli() = %v", got)
+	}
+	if got := timefy.FromUnixMicro(1692107130123456); !got.Equal(want.Add(123456 * time.Microsecond)) {
+		t.Errorf("FromUnixMicro() = %v", got)
+	}
+	tests := []struct {
+		n    int64
+		want time.Time
+	}{
+		{1692107130, want},
+		{1692107130123, want.Add(123 * time.Millisecond)},
+		{1692107130123456, want.Add(123456 * time.Microsecond)},
+		{1692107130123456789, want.Add(123456789)},
+		{-1692107130, time.Unix(-1692107130, 0).UTC()},
+		{0, time.Unix(0, 0).UTC()},
+		{math.MinInt64, time.Unix(0, math.MinInt64).UTC()},
+	}
+	for _, tt := range tests {
+		if got := timefy.FromUnixAuto(tt.n); !got.Equal(tt.want) || got.Location() != time.UTC {
+			t.Errorf("FromUnixAuto(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSinceDailyReset(t *testing.T) {
+	v := utc(2023, time.August, 15, 20, 0, 0)
+	tests := []struct {
+		v       time.Time
+		zone    timefy.ZoneRFC
+		want    time.Duration
+		wantErr bool
+	}{
+		{v, "UTC", 20 * time.Hour, false},
+		{v, "Asia/Tokyo", 5 * time.Hour, false},
+		{v, "America/New_York", 16 * time.Hour, false},
+		// 03:30 EDT on a spring-forward day: only 2h30m have elapsed since midnight.
+		{utc(2023, time.March, 12, 7, 30, 0), "America/New_York", 2*time.Hour + 30*time.Minute, false},
+		{v, "Mars/Olympus_Mons", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := timefy.SinceDailyReset(tt.v, tt.zone)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("SinceDailyReset(%v, %s) = %v, %v; want %v, error %v", tt.v, tt.zone, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAddMonthsYears(t *testing.T) {
+	tests := []struct {
+		v             time.Time
+		months, years int
+		wantM, wantY  time.Time
+	}{
+		{utc(2024, time.January, 31, 10, 0, 0), 1, 1, utc(2024, time.February, 29, 10, 0, 0), utc(2025, time.January, 31, 10, 0, 0)},
+		{utc(2023, time.January, 31, 10, 0, 0), 1, -1, utc(2023, time.February, 28, 10, 0, 0), utc(2022, time.January, 31, 10, 0, 0)},
+		{utc(2024, time.March, 31, 0, 0, 0), -1, 0, utc(2024, time.February, 29, 0, 0, 0), utc(2024, time.March, 31, 0, 0, 0)},
+		{utc(2024, time.February, 29, 0, 0, 0), 12, 1, utc(2025, time.February, 28, 0, 0, 0), utc(2025, time.February, 28, 0, 0, 0)},
+		{utc(2023, time.December, 15, 0, 0, 0), 14, 4, utc(2025, time.February, 15, 0, 0, 0), utc(2027, time.December, 15, 0, 0, 0)},
+	}
+	config := &timefy.Config{WeekStartDay: time.Monday}
+	for _, tt := range tests {
+		tx := config.With(tt.v)
+		if got := tx.AddMonths(tt.months); !got.Equal(tt.wantM) || got.Config != config {
+			t.Errorf("AddMonths(%v, %d) = %v, want %v", tt.v, tt.months, got.Time, tt.wantM)
+		}
+		if got := tx.AddYears(tt.years); !got.Equal(tt.wantY) || got.Config != config {
+			t.Errorf("AddYears(%v, %d) = %v, want %v", tt.v, tt.years, got.Time, tt.wantY)
+		}
+	}
+}
+
+func TestExactSeconds(t *testing.T) {
+	ref := utc(2023, time.October, 25, 15, 0, 0)
+	freezeClock(t, ref)
+	french := frenchLocale
+	french.Soon = "dans quelques secondes"
+	tests := []struct {
+		offset time.Duration
+		exact  bool
+		locale timefy.Locale
+		want   string
+	}{
+		{-30 * time.Second, false, timefy.EnglishLocale, "just now"},
+		{-30 * time.Second, true, timefy.EnglishLocale, "30 seconds ago"},
+		{-time.Second, true, timefy.EnglishLocale, "1 second ago"},
+		{-500 * time.Millisecond, true, timefy.EnglishLocale, "just now"},
+		{30 * time.Second, false, timefy.EnglishLocale, "in a few seconds"},
+		{30 * time.Second, true, timefy.EnglishLocale, "in 30 seconds"},
+		// The French locale has no Second unit: the vague labels are kept.
+		{-30 * time.Second, false, french, "à l'instant"},
+		{-30 * time.Second, true, french, "à l'instant"},
+		{30 * time.Second, true, french, "dans quelques secondes"},
+	}
+	for _, tt := range tests {
+		tx := (&timefy.Config{}).WithExactSeconds(tt.exact).With(ref.Add(tt.offset))
+		if got := tx.TimeAgoLocale(tt.locale); got != tt.want {
+			t.Errorf("TimeAgoLocale(%v) with exact seconds %v = %q, want %q", tt.offset, tt.exact, got, tt.want)
+		}
+	}
+}
+
+func TestQuarterShifts(t *testing.T) {
+	tests := []struct {
+		v           time.Time
+		n           int
+		added       time.Time
+		next        time.Time
+		previousEnd time.Time
+	}{
+		{utc(2023, time.November, 15, 10, 0, 0), 1, utc(2024, time.February, 15, 10, 0, 0), utc(2024, time.January, 1, 0, 0, 0), utc(2023, time.October, 1, 0, 0, 0)},
+		{utc(2023, time.November, 30, 10, 0, 0), 1, utc(2024, time.February, 29, 10, 0, 0), utc(2024, time.January, 1, 0, 0, 0), utc(2023, time.October, 1, 0, 0, 0)},
+		{utc(2023, time.November, 15, 10, 0, 0), -4, utc(2022, time.November, 15, 10, 0, 0), utc(2024, time.January, 1, 0, 0, 0), utc(2023, time.October, 1, 0, 0, 0)},
+		{utc(2024, time.February, 15, 10, 0, 0), 0, utc(2024, time.February, 15, 10, 0, 0), utc(2024, time.April, 1, 0, 0, 0), utc(2024, time.January, 1, 0, 0, 0)},
+	}
+	for _, tt := range tests {
+		tx := timefy.With(tt.v)
+		if got := tx.AddQuarters(tt.n); !got.Equal(tt.added) {
+			t.Errorf("AddQuarters(%v, %d) = %v, want %v", tt.v, tt.n, got, tt.added)
+		}
+		if got := tx.BeginningOfNextQuarter(); !got.Equal(tt.next) {
+			t.Errorf("BeginningOfNextQuarter(%v) = %v, want %v", tt.v, got, tt.next)
+		}
+		if got := tx.EndOfPreviousQuarter(); !got.Equal(tt.previousEnd.Add(-time.Nanosecond)) {
+			t.Errorf("EndOfPreviousQuarter(%v) = %v, want %v", tt.v, got, tt.previousEnd.Add(-time.Nanosecond))
+		}
+	}
+}
+
+func TestRangeDurationMidpoint(t *testing.T) {
+	tests := []struct {
+		r        timefy.Range
+		duration time.Duration
+		midpoint time.Time
+	}{
+		{hours(9, 10), time.Hour, hours(9.5, 9.5).Start},
+		{hours(9, 9), 0, hours(9, 9).Start},
+		{hours(10, 9), -time.Hour, hours(9.5, 9.5).Start},
+		{hours(22, 25), 3 * time.Hour, hours(23.5, 23.5).Start},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Duration(); got != tt.duration {
+			t.Errorf("%v.Duration() = %v, want %v", tt.r, got, tt.duration)
+		}
+		if got := tt.r.Midpoint(); !got.Equal(tt.midpoint) {
+			t.Errorf("%v.Midpoint() = %v, want %v", tt.r, got, tt.midpoint)
+		}
+	}
+}
+
+func TestTruncateRoundTo(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+1800)
+	ny := loadLocation(t, "America/New_York")
+	v := time.Date(2023, time.October, 25, 10, 40, 0, 0, ist)
+	// On the fall-back day, 23:30 EST is 24h30m after midnight.
+	late := time.Date(2023, time.November, 5, 23, 30, 0, 0, ny)
+	tests := []struct {
+		v         time.Time
+		d         time.Duration
+		truncated time.Time
+		rounded   time.Time
+	}{
+		{v, time.Hour, time.Date(2023, time.October, 25, 10, 0, 0, 0, ist), time.Date(2023, time.October, 25, 11, 0, 0, 0, ist)},
+		{v, 15 * time.Minute, time.Date(2023, time.October, 25, 10, 30, 0, 0, ist), time.Date(2023, time.October, 25, 10, 45, 0, 0, ist)},
+		{v, 24 * time.Hour, time.Date(2023, time.October, 25, 0, 0, 0, 0, ist), time.Date(2023, time.October, 25, 0, 0, 0, 0, ist)},
+		{v, 0, v, v},
+		{late, 24 * time.Hour, time.Date(2023, time.November, 5, 0, 0, 0, 0, ny), time.Date(2023, time.November, 6, 0, 0, 0, 0, ny)},
+		{late, time.Hour, time.Date(2023, time.November, 5, 23, 0, 0, 0, ny), time.Date(2023, time.November, 6, 0, 0, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		if got := timefy.TruncateTo(tt.v, tt.d); !got.Equal(tt.truncated) {
+			t.Errorf("TruncateTo(%v, %v) = %v, want %v", tt.v, tt.d, got, tt.truncated)
+		}
+		if got := timefy.RoundTo(tt.v, tt.d); !got.Equal(tt.rounded) {
+			t.Errorf("RoundTo(%v, %v) = %v, want %v", tt.v, tt.d, got, tt.rounded)
+		}
+	}
+	// The stdlib counts multiples from the zero time, in effect in UTC.
+	if got := v.Truncate(time.Hour); got.Equal(timefy.TruncateTo(v, time.Hour)) {
+		t.Errorf("time.Truncate(%v, 1h) = %v, expected it to differ from TruncateTo", v, got)
+	}
+}
+
+func TestRangeClampTo(t *testing.T) {
+	bounds := hours(9, 17)
+	tests := []struct {
+		r    timefy.Range
+		want timefy.Range
+		ok   bool
+	}{
+		{hours(7, 10), hours(9, 10), true},
+		{hours(16, 20), hours(16, 17), true},
+		{hours(10, 12), hours(10, 12), true},
+		{hours(8, 18), hours(9, 17), true},
+		{hours(7, 9), timefy.Range{}, false},
+		{hours(17, 18), timefy.Range{}, false},
+		{hours(5, 6), timefy.Range{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.r.ClampTo(bounds)
+		if ok != tt.ok || !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+			t.Errorf("%v.ClampTo() = %v, %v; want %v, %v", tt.r, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestTimeRange(t *testing.T) {
+	r := timefy.NewTimeRange(hours(11, 11).Start, hours(9, 9).Start)
+	if !r.Start.Equal(hours(9, 9).Start) || !r.End.Equal(hours(11, 11).Start) {
+		t.Errorf("NewTimeRange() did not normalize the bounds: %v", r)
+	}
+	contains := []struct {
+		at   float64
+		want bool
+	}{
+		{8.5, false},
+		{9, true},
+		{10, true},
+		{11, false},
+	}
+	for _, tt := range contains {
+		if got := r.Contains(hours(tt.at, tt.at).Start); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+	overlaps := []struct {
+		other  timefy.TimeRange
+		want   bool
+		common timefy.TimeRange
+	}{
+		{hours(10, 12), true, hours(10, 11)},
+		{hours(8, 9), false, timefy.TimeRange{}},
+		{hours(11, 12), false, timefy.TimeRange{}},
+		{hours(9.5, 10), true, hours(9.5, 10)},
+	}
+	for _, tt := range overlaps {
+		if got := r.Overlaps(tt.other); got != tt.want || tt.other.Overlaps(r) != tt.want {
+			t.Errorf("Overlaps(%v) = %v, want %v", tt.other, got, tt.want)
+		}
+		if got, ok := r.Intersection(tt.other); ok != tt.want || !got.Start.Equal(tt.common.Start) || !got.End.Equal(tt.common.End) {
+			t.Errorf("Intersection(%v) = %v, %v; want %v, %v", tt.other, got, ok, tt.common, tt.want)
+		}
+	}
+}
+
+func TestIsWeekdayOf(t *testing.T) {
+	wednesday := utc(2023, time.October, 25, 23, 0, 0)
+	tests := []struct {
+		v       time.Time
+		weekday time.Weekday
+		want    bool
+	}{
+		{wednesday, time.Wednesday, true},
+		{wednesday, time.Thursday, false},
+		{wednesday.In(loadLocation(t, "Asia/Tokyo")), time.Thursday, true},
+		{utc(2023, time.October, 29, 0, 0, 0), time.Sunday, true},
+	}
+	for _, tt := range tests {
+		if got := timefy.IsWeekdayOf(tt.v, tt.weekday); got != tt.want {
+			t.Errorf("IsWeekdayOf(%v, %v) = %v, want %v", tt.v, tt.weekday, got, tt.want)
+		}
+		if got := timefy.With(tt.v).Is(tt.weekday); got != tt.want {
+			t.Errorf("Is(%v, %v) = %v, want %v", tt.v, tt.weekday, got, tt.want)
+		}
+	}
+}
+
+func TestPeriodBoundariesInRange(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	at := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, ny)
+	}
+	fallBack, springForward := at(2023, time.November, 5, 0, 0), at(2023, time.March, 12, 0, 0)
+	// The second 01:30 of the fall-back day, in EST.
+	repeated := at(2023, time.November, 5, 1, 30).Add(time.Hour)
+	year := timefy.Range{Start: at(2023, time.January, 1, 0, 0), End: at(2024, time.January, 1, 0, 0)}
+	tests := []struct {
+		name   string
+		start  time.Time
+		end    time.Time
+		period timefy.Period
+		want   int
+	}{
+		{"fall-back minutes", fallBack, fallBack.AddDate(0, 0, 1), timefy.PeriodMinute, 25 * 60},
+		{"fall-back hours", fallBack, fallBack.AddDate(0, 0, 1), timefy.PeriodHour, 25},
+		{"fall-back days", fallBack, fallBack.AddDate(0, 0, 1), timefy.PeriodDay, 1},
+		{"repeated hour", repeated, at(2023, time.November, 5, 3, 0), timefy.PeriodHour, 2},
+		{"spring-forward minutes", springForward, springForward.AddDate(0, 0, 1), timefy.PeriodMinute, 23 * 60},
+		{"spring-forward hours", springForward, springForward.AddDate(0, 0, 1), timefy.PeriodHour, 23},
+		{"spring-forward days", springForward, springForward.AddDate(0, 0, 1), timefy.PeriodDay, 1},
+		{"year minutes", year.Start, year.End, timefy.PeriodMinute, 365 * 24 * 60},
+		{"year hours", year.Start, year.End, timefy.PeriodHour, 365 * 24},
+		{"year days", year.Start, year.End, timefy.PeriodDay, 365},
+		{"year weeks", year.Start, year.End, timefy.PeriodWeek, 52},
+		{"year months", year.Start, year.End, timefy.PeriodMonth, 12},
+		{"year quarters", year.Start, year.End, timefy.PeriodQuarter, 4},
+		{"year halves", year.Start, year.End, timefy.PeriodHalf, 2},
+		{"year", year.Start, year.End, timefy.PeriodYear, 1},
+		{"empty", year.End, year.Start, timefy.PeriodDay, 0},
+		{"unknown period", year.Start, year.End, timefy.Period("fortnight"), 0},
+	}
+	for _, tt := range tests {
+		if got := timefy.PeriodBoundariesInRange(tt.start, tt.end, tt.period, ny); got != tt.want {
+			t.Errorf("%s: PeriodBoundariesInRange(%v, %v, %s) = %d, want %d", tt.name, tt.start, tt.end, tt.period, got, tt.want)
+		}
+	}
+	start := utc(2023, time.October, 25, 10, 0, 0)
+	if got := timefy.PeriodBoundariesInRange(start, start.AddDate(0, 0, 90), timefy.PeriodMonth, nil); got != 3 {
+		t.Errorf("PeriodBoundariesInRange() with a nil location = %d, want 3", got)
+	}
+}
+
+func TestSplitByDay(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	midnight := func(month time.Month, day int) time.Time {
+		return time.Date(2023, month, day, 0, 0, 0, 0, ny)
+	}
+	tests := []struct {
+		name      string
+		r         timefy.TimeRange
+		durations []time.Duration
+	}{
+		{"single day", hours(9, 17), []time.Duration{8 * time.Hour}},
+		{"two days", hours(20, 30), []time.Duration{4 * time.Hour, 6 * time.Hour}},
+		{"empty", hours(9, 9), []time.Duration{0}},
+		{"inverted", hours(10, 9), nil},
+		{"fall-back day", timefy.TimeRange{Start: midnight(time.November, 4), End: midnight(time.November, 7)}, []time.Duration{24 * time.Hour, 25 * time.Hour, 24 * time.Hour}},
+		{"spring-forward day", timefy.TimeRange{Start: midnight(time.March, 11).Add(12 * time.Hour), End: midnight(time.March, 13).Add(12 * time.Hour)}, []time.Duration{12 * time.Hour, 23 * time.Hour, 12 * time.Hour}},
+	}
+	for _, tt := range tests {
+		parts := tt.r.SplitByDay()
+		if len(parts) != len(tt.durations) {
+			t.Errorf("%s: SplitByDay() = %v, want %d parts", tt.name, parts, len(tt.durations))
+			continue
+		}
+		for i, part := range parts {
+			if part.Duration() != tt.durations[i] {
+				t.Errorf("%s: part %d = %v, want a duration of %v", tt.name, i, part, tt.durations[i])
+			}
+			if i > 0 && (!part.Start.Equal(parts[i-1].End) || part.Start.Hour() != 0 || part.Start.Minute() != 0) {
+				t.Errorf("%s: part %d does not start at midnight after the previous part: %v", tt.name, i, part)
+			}
+		}
+	}
+}
+
+func TestIsSame(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	tests := []struct {
+		a, b             time.Time
+		day, month, year bool
+	}{
+		{utc(2023, time.October, 25, 1, 0, 0), utc(2023, time.October, 25, 23, 0, 0), true, true, true},
+		{utc(2023, time.October, 25, 0, 0, 0), utc(2023, time.October, 26, 0, 0, 0), false, true, true},
+		{utc(2023, time.October, 31, 0, 0, 0), utc(2023, time.November, 1, 0, 0, 0), false, false, true},
+		{utc(2023, time.December, 31, 0, 0, 0), utc(2024, time.January, 1, 0, 0, 0), false, false, false},
+		{utc(2022, time.October, 25, 0, 0, 0), utc(2023, time.October, 25, 0, 0, 0), false, false, false},
+		// b is evaluated in the location of a: 20:00 UTC on Dec 31st is already Jan 1st in Tokyo.
+		{time.Date(2024, time.January, 1, 8, 0, 0, 0, tokyo), utc(2023, time.December, 31, 20, 0, 0), true, true, true},
+	}
+	for _, tt := range tests {
+		if got := timefy.IsSameDay(tt.a, tt.b); got != tt.day {
+			t.Errorf("IsSameDay(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.day)
+		}
+		if got := timefy.IsSameMonth(tt.a, tt.b); got != tt.month {
+			t.Errorf("IsSameMonth(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.month)
+		}
+		if got := timefy.IsSameYear(tt.a, tt.b); got != tt.year {
+			t.Errorf("IsSameYear(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.year)
+		}
+	}
+}
+
+func TestRemainingFraction(t *testing.T) {
+	start := utc(2023, time.October, 25, 9, 0, 0)
+	end := start.Add(2 * time.Hour)
+	tests := []struct {
+		start, end, at time.Time
+		want           float64
+	}{
+		{start, end, start.Add(-time.Hour), 1},
+		{start, end, start, 1},
+		{start, end, start.Add(30 * time.Minute), 0.75},
+		{start, end, start.Add(time.Hour), 0.5},
+		{start, end, end, 0},
+		{start, end, end.Add(time.Hour), 0},
+		{start, start, start.Add(-time.Second), 1},
+		{start, start, start, 0},
+		{end, start, start.Add(-time.Second), 1},
+		{end, start, start.Add(time.Hour), 0},
+	}
+	for _, tt := range tests {
+		if got := timefy.RemainingFraction(tt.start, tt.end, tt.at); got != tt.want {
+			t.Errorf("RemainingFraction(%v, %v, %v) = %v, want %v", tt.start, tt.end, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRelative(t *testing.T) {
+	ref := utc(2023, time.October, 25, 15, 0, 0)
+	freezeClock(t, ref)
+	tests := []struct {
+		offset time.Duration
+		exact  bool
+		want   string
+	}{
+		{-2 * time.Hour, false, "2 hours ago"},
+		{2 * time.Hour, false, "in 2 hours"},
+		{10 * time.Second, false, "just now"},
+		{-10 * time.Second, false, "just now"},
+		{10 * time.Second, true, "in 10 seconds"},
+		{-10 * time.Second, true, "10 seconds ago"},
+		{0, true, "just now"},
+		{3 * 24 * time.Hour, false, "in 3 days"},
+	}
+	for _, tt := range tests {
+		tx := (&timefy.Config{}).WithExactSeconds(tt.exact).With(ref.Add(tt.offset))
+		if got := tx.FormatRelative(); got != tt.want {
+			t.Errorf("FormatRelative(%v) with exact seconds %v = %q, want %q", tt.offset, tt.exact, got, tt.want)
+		}
+	}
+}
+
+func TestParseAssumeZone(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	config := &timefy.Config{TimeFormats: timefy.TimeFormats}
+	tests := []struct {
+		input   string
+		zone    timefy.ZoneRFC
+		want    time.Time
+		wantErr bool
+	}{
+		{"2023-10-25 14:30:00", "Asia/Tokyo", time.Date(2023, time.October, 25, 14, 30, 0, 0, tokyo), false},
+		{"2023-10-25 14:30:00", "UTC", utc(2023, time.October, 25, 14, 30, 0), false},
+		{"2023-10-25T14:30:00+07:00", "Asia/Tokyo", time.Date(2023, time.October, 25, 14, 30, 0, 0, time.FixedZone("", 7*3600)), false},
+		{"2023-10-25 14:30:00", "Mars/Olympus_Mons", time.Time{}, true},
+		{"not a time", "Asia/Tokyo", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := config.ParseAssumeZone(tt.input, tt.zone)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("ParseAssumeZone(%q, %s) = %v, %v; want %v, error %v", tt.input, tt.zone, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestRecur(t *testing.T) {
+	start := utc(2024, time.January, 31, 9, 0, 0)
+	monthly := func(v time.Time) time.Time { return timefy.With(v).AddMonths(1).Time }
+	daily := func(v time.Time) time.Time { return v.AddDate(0, 0, 1) }
+	stuck := func(v time.Time) time.Time { return v }
+	tests := []struct {
+		name     string
+		step     func(time.Time) time.Time
+		until    time.Time
+		maxCount int
+		want     []time.Time
+	}{
+		{"by count", monthly, time.Time{}, 3, []time.Time{start, utc(2024, time.February, 29, 9, 0, 0), utc(2024, time.March, 29, 9, 0, 0)}},
+		{"by date", daily, start.AddDate(0, 0, 2), 0, []time.Time{start, start.AddDate(0, 0, 1), start.AddDate(0, 0, 2)}},
+		{"count before date", daily, start.AddDate(0, 0, 10), 2, []time.Time{start, start.AddDate(0, 0, 1)}},
+		{"until before start", daily, start.Add(-time.Hour), 5, nil},
+		{"unbounded", daily, time.Time{}, 0, nil},
+		{"step not moving forward", stuck, time.Time{}, 5, []time.Time{start}},
+	}
+	for _, tt := range tests {
+		got := timefy.Recur(start, tt.step, tt.until, tt.maxCount)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: Recur() = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equal(tt.want[i]) {
+				t.Errorf("%s: Recur()[%d] = %v, want %v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestTimeAgoCalendarSpans(t *testing.T) {
+	ref := utc(2023, time.October, 25, 15, 0, 0)
+	freezeClock(t, ref)
+	tests := []struct {
+		days       int
+		ago, until string
+		short      string
+	}{
+		{29, "4 weeks ago", "in 4 weeks", "4w"},
+		{45, "1 month ago", "in 1 month", "1mo"},
+		{366, "12 months ago", "in 12 months", "12mo"},
+		{400, "13 months ago", "in 13 months", "13mo"},
+		{700, "23 months ago", "in 22 months", "23mo"},
+		{731, "2 years ago", "in 2 years", "2y"},
+		{800, "2 years ago", "in 2 years", "2y"},
+		{1200, "3 years ago", "in 3 years", "3y"},
+	}
+	for _, tt := range tests {
+		past := timefy.With(ref.AddDate(0, 0, -tt.days))
+		if got := past.TimeAgo(); got != tt.ago {
+			t.Errorf("TimeAgo(-%d days) = %q, want %q", tt.days, got, tt.ago)
+		}
+		if got := past.TimeAgoShort(); got != tt.short {
+			t.Errorf("TimeAgoShort(-%d days) = %q, want %q", tt.days, got, tt.short)
+		}
+		if got := timefy.With(ref.AddDate(0, 0, tt.days)).TimeUntil(); got != tt.until {
+			t.Errorf("TimeUntil(%d days) = %q, want %q", tt.days, got, tt.until)
+		}
+	}
+}
+
+func TestDecadeCentury(t *testing.T) {
+	tests := []struct {
+		year            int
+		decade, century int
+	}{
+		{2023, 2020, 2000},
+		{2020, 2020, 2000},
+		{2029, 2020, 2000},
+		{2000, 2000, 2000},
+		{1999, 1990, 1900},
+		{2100, 2100, 2100},
+		{-5, -10, -100},
+	}
+	for _, tt := range tests {
+		tx := timefy.With(time.Date(tt.year, time.October, 25, 12, 0, 0, 0, time.UTC))
+		decade := utc(tt.decade, time.January, 1, 0, 0, 0)
+		century := utc(tt.century, time.January, 1, 0, 0, 0)
+		if got := tx.BeginningOfDecade(); !got.Equal(decade) {
+			t.Errorf("BeginningOfDecade(%d) = %v, want %v", tt.year, got, decade)
+		}
+		if got := tx.EndOfDecade(); !got.Equal(decade.AddDate(10, 0, 0).Add(-time.Nanosecond)) {
+			t.Errorf("EndOfDecade(%d) = %v", tt.year, got)
+		}
+		if got := tx.BeginningOfCentury(); !got.Equal(century) {
+			t.Errorf("BeginningOfCentury(%d) = %v, want %v", tt.year, got, century)
+		}
+		if got := tx.EndOfCentury(); !got.Equal(century.AddDate(100, 0, 0).Add(-time.Nanosecond)) {
+			t.Errorf("EndOfCentury(%d) = %v", tt.year, got)
+		}
+	}
+}
+
+func TestZoneOffsetDiff(t *testing.T) {
+	summer, winter := utc(2023, time.July, 1, 12, 0, 0), utc(2023, time.January, 1, 12, 0, 0)
+	tests := []struct {
+		v       time.Time
+		a, b    timefy.ZoneRFC
+		want    time.Duration
+		wantErr bool
+	}{
+		{summer, "Europe/London", "Asia/Tokyo", 8 * time.Hour, false},
+		{winter, "Europe/London", "Asia/Tokyo", 9 * time.Hour, false},
+		{winter, "Asia/Tokyo", "Europe/London", -9 * time.Hour, false},
+		{winter, "UTC", "Asia/Kolkata", 5*time.Hour + 30*time.Minute, false},
+		{summer, "UTC", "UTC", 0, false},
+		{summer, "Mars/Olympus_Mons", "UTC", 0, true},
+		{summer, "UTC", "Mars/Olympus_Mons", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := timefy.ZoneOffsetDiff(tt.v, tt.a, tt.b)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ZoneOffsetDiff(%v, %s, %s) = %v, %v; want %v, error %v", tt.v, tt.a, tt.b, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNextBusinessTimeOfDay(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	nine := timefy.ClockTime{Hour: 9}
+	tests := []struct {
+		ref  time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		{utc(2023, time.October, 25, 8, 0, 0), time.UTC, utc(2023, time.October, 25, 9, 0, 0)},
+		{utc(2023, time.October, 25, 9, 0, 0), time.UTC, utc(2023, time.October, 26, 9, 0, 0)},
+		{utc(2023, time.October, 27, 15, 0, 0), time.UTC, utc(2023, time.October, 30, 9, 0, 0)},
+		{utc(2023, time.October, 28, 8, 0, 0), nil, utc(2023, time.October, 30, 9, 0, 0)},
+		// Friday 23:00 UTC is already Saturday in Tokyo: the next business morning is Monday there.
+		{utc(2023, time.October, 27, 23, 0, 0), tokyo, time.Date(2023, time.October, 30, 9, 0, 0, 0, tokyo)},
+	}
+	for _, tt := range tests {
+		if got := timefy.NextBusinessTimeOfDay(tt.ref, nine, tt.loc); !got.Equal(tt.want) {
+			t.Errorf("NextBusinessTimeOfDay(%v, %v) = %v, want %v", tt.ref, tt.loc, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDurationUnits(t *testing.T) {
+	d := 26*time.Hour + 3*time.Minute + 4*time.Second
+	tests := []struct {
+		d        time.Duration
+		maxUnits int
+		want     string
+	}{
+		{0, 2, "0s"},
+		{d, 2, "1d2h"},
+		{d, 0, "1d2h3m4s"},
+		{-d, 1, "-1d"},
+		{24*time.Hour + 3*time.Minute, 2, "1d"},
+		{1500 * time.Microsecond, 0, "1ms500us"},
+		{time.Nanosecond, 3, "1ns"},
+		{math.MinInt64, 1, "-106751d"},
+	}
+	for _, tt := range tests {
+		if got := timefy.FormatDurationUnits(tt.d, tt.maxUnits); got != tt.want {
+			t.Errorf("FormatDurationUnits(%v, %d) = %q, want %q", tt.d, tt.maxUnits, got, tt.want)
+		}
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	freezeClock(t, utc(2023, time.October, 25, 8, 0, 0))
+	config := &timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}
+	tests := []struct {
+		input  string
+		want   time.Time
+		layout string
+	}{
+		{"2023-10-25 14:30", utc(2023, time.October, 25, 14, 30, 0), "2006-1-2 15:4"},
+		{"20231025", utc(2023, time.October, 25, 0, 0, 0), "20060102"},
+	}
+	for _, tt := range tests {
+		got, layout, err := config.ParseAny(tt.input)
+		if err != nil || !got.Equal(tt.want) || layout != tt.layout {
+			t.Errorf("ParseAny(%q) = %v, %q, %v; want %v, %q", tt.input, got, layout, err, tt.want, tt.layout)
+		}
+	}
+	_, layout, err := config.ParseAny("not a time")
+	if err == nil || layout != "" || !strings.Contains(err.Error(), fmt.Sprintf("tried %d formats", len(timefy.TimeFormats))) {
+		t.Errorf("ParseAny(invalid) = %q, %v", layout, err)
+	}
+}
+
+func TestBusinessDayStart(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	tests := []struct {
+		v    time.Time
+		hour int
+		loc  *time.Location
+		want time.Time
+	}{
+		{utc(2023, time.October, 25, 2, 0, 0), 4, time.UTC, utc(2023, time.October, 24, 4, 0, 0)},
+		{utc(2023, time.October, 25, 4, 0, 0), 4, time.UTC, utc(2023, time.October, 25, 4, 0, 0)},
+		{utc(2023, time.October, 25, 23, 0, 0), 4, nil, utc(2023, time.October, 25, 4, 0, 0)},
+		{utc(2023, time.October, 25, 12, 0, 0), 0, time.UTC, utc(2023, time.October, 25, 0, 0, 0)},
+		// 20:00 UTC is 05:00 on the 26th in Tokyo.
+		{utc(2023, time.October, 25, 20, 0, 0), 4, tokyo, time.Date(2023, time.October, 26, 4, 0, 0, 0, tokyo)},
+	}
+	for _, tt := range tests {
+		if got := timefy.BusinessDayStart(tt.v, tt.hour, tt.loc); !got.Equal(tt.want) {
+			t.Errorf("BusinessDayStart(%v, %d, %v) = %v, want %v", tt.v, tt.hour, tt.loc, got, tt.want)
+		}
+	}
+}
+
+func TestConfigClone(t *testing.T) {
+	if (*timefy.Config)(nil).Clone() != nil {
+		t.Error("Clone() of a nil configuration is not nil")
+	}
+	base := &timefy.Config{WeekStartDay: time.Monday, TimeLocation: time.UTC, TimeFormats: timefy.TimeFormats[:2]}
+	clone := base.Clone()
+	if clone == base || clone.WeekStartDay != time.Monday || clone.TimeLocation != time.UTC || len(clone.TimeFormats) != 2 {
+		t.Fatalf("Clone() = %+v", clone)
+	}
+	clone.TimeFormats[0] = "changed"
+	clone.WeekStartDay = time.Sunday
+	if base.TimeFormats[0] == "changed" || base.WeekStartDay != time.Monday {
+		t.Error("modifying the clone changed the original configuration")
+	}
+	// AppendTimeFormat must not write into the spare capacity of the shared package-level slice.
+	shared := timefy.TimeFormats[:1]
+	first := (&timefy.Config{TimeFormats: shared}).AppendTimeFormat("02 Jan 2006")
+	second := (&timefy.Config{TimeFormats: shared}).AppendTimeFormatRFC(timefy.TimeFormat20060102T150405)
+	if timefy.TimeFormats[1] == "02 Jan 2006" || first.TimeFormats[1] != "02 Jan 2006" || second.TimeFormats[1] != string(timefy.TimeFormat20060102T150405) {
+		t.Errorf("AppendTimeFormat is not copy-on-write: %q, %q, %q", timefy.TimeFormats[1], first.TimeFormats[1], second.TimeFormats[1])
+	}
+	config := (&timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}).AppendTimeFormat("02 Jan 2006 15:04")
+	if v, err := config.Parse("25 Oct 2023 14:30"); err != nil || !v.Equal(utc(2023, time.October, 25, 14, 30, 0)) {
+		t.Errorf("Parse() with an appended layout = %v, %v", v, err)
+	}
+}
+
+func TestBusinessDate(t *testing.T) {
+	tests := []struct {
+		v    time.Time
+		hour int
+		want time.Time
+	}{
+		{utc(2023, time.October, 25, 3, 0, 0), 4, utc(2023, time.October, 24, 0, 0, 0)},
+		{utc(2023, time.October, 25, 4, 0, 0), 4, utc(2023, time.October, 25, 0, 0, 0)},
+		{utc(2023, time.October, 1, 2, 0, 0), 6, utc(2023, time.September, 30, 0, 0, 0)},
+		{utc(2023, time.October, 25, 23, 59, 59), 0, utc(2023, time.October, 25, 0, 0, 0)},
+	}
+	for _, tt := range tests {
+		if got := timefy.BusinessDate(tt.v, tt.hour, time.UTC); !got.Equal(tt.want) {
+			t.Errorf("BusinessDate(%v, %d) = %v, want %v", tt.v, tt.hour, got, tt.want)
+		}
+	}
+}
+
+func TestClockTimeNormalize(t *testing.T) {
+	tests := []struct {
+		c     timefy.ClockTime
+		want  timefy.ClockTime
+		days  int
+		valid bool
+	}{
+		{timefy.ClockTime{Hour: 9, Minute: 30}, timefy.ClockTime{Hour: 9, Minute: 30}, 0, true},
+		{timefy.ClockTime{Hour: 23, Minute: 59, Second: 59}, timefy.ClockTime{Hour: 23, Minute: 59, Second: 59}, 0, true},
+		{timefy.ClockTime{Hour: 25, Minute: 70}, timefy.ClockTime{Hour: 2, Minute: 10}, 1, false},
+		{timefy.ClockTime{Hour: 24}, timefy.ClockTime{}, 1, false},
+		{timefy.ClockTime{Hour: -1}, timefy.ClockTime{Hour: 23}, -1, false},
+		{timefy.ClockTime{Second: -1}, timefy.ClockTime{Hour: 23, Minute: 59, Second: 59}, -1, false},
+		{timefy.ClockTime{Hour: 49}, timefy.ClockTime{Hour: 1}, 2, false},
+	}
+	for _, tt := range tests {
+		got, days := tt.c.Normalize()
+		if got != tt.want || days != tt.days {
+			t.Errorf("%+v.Normalize() = %+v, %d; want %+v, %d", tt.c, got, days, tt.want, tt.days)
+		}
+		if !got.Valid() {
+			t.Errorf("%+v.Normalize() = %+v is not valid", tt.c, got)
+		}
+		if tt.c.Valid() != tt.valid {
+			t.Errorf("%+v.Valid() = %v, want %v", tt.c, tt.c.Valid(), tt.valid)
+		}
+	}
+}
+
+func TestNextPreviousWeekday(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	wednesday := utc(2023, time.October, 25, 10, 0, 0)
+	tests := []struct {
+		v              time.Time
+		day            time.Weekday
+		next, previous time.Time
+	}{
+		{wednesday, time.Tuesday, utc(2023, time.October, 31, 10, 0, 0), utc(2023, time.October, 24, 10, 0, 0)},
+		{wednesday, time.Wednesday, utc(2023, time.November, 1, 10, 0, 0), utc(2023, time.October, 18, 10, 0, 0)},
+		{wednesday, time.Thursday, utc(2023, time.October, 26, 10, 0, 0), utc(2023, time.October, 19, 10, 0, 0)},
+		// The wall clock is kept across the fall-back transition of November 5th.
+		{time.Date(2023, time.November, 3, 9, 0, 0, 0, ny), time.Monday, time.Date(2023, time.November, 6, 9, 0, 0, 0, ny), time.Date(2023, time.October, 30, 9, 0, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		if got := timefy.NextWeekday(tt.v, tt.day); !got.Equal(tt.next) {
+			t.Errorf("NextWeekday(%v, %v) = %v, want %v", tt.v, tt.day, got, tt.next)
+		}
+		if got := timefy.PreviousWeekday(tt.v, tt.day); !got.Equal(tt.previous) {
+			t.Errorf("PreviousWeekday(%v, %v) = %v, want %v", tt.v, tt.day, got, tt.previous)
+		}
+	}
+}
+
+func TestDaytimeBetween(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	open, close := timefy.ClockTime{Hour: 8}, timefy.ClockTime{Hour: 20}
+	friday := utc(2023, time.October, 27, 18, 0, 0)
+	tests := []struct {
+		name        string
+		start, end  time.Time
+		open, close timefy.ClockTime
+		loc         *time.Location
+		want        time.Duration
+	}{
+		{"over a weekend", friday, utc(2023, time.October, 29, 10, 0, 0), open, close, time.UTC, 16 * time.Hour},
+		{"reversed", utc(2023, time.October, 29, 10, 0, 0), friday, open, close, nil, -16 * time.Hour},
+		{"night only", utc(2023, time.October, 25, 20, 0, 0), utc(2023, time.October, 26, 8, 0, 0), open, close, time.UTC, 0},
+		{"empty window", friday, utc(2023, time.October, 29, 10, 0, 0), close, open, time.UTC, 0},
+		// The window covers 01:00-03:00 local time, which lasts 3 hours on the fall-back day.
+		{"fall-back day", time.Date(2023, time.November, 5, 0, 0, 0, 0, ny), time.Date(2023, time.November, 6, 0, 0, 0, 0, ny), timefy.ClockTime{Hour: 1}, timefy.ClockTime{Hour: 3}, nil, 3 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := timefy.DaytimeBetween(tt.start, tt.end, tt.open, tt.close, tt.loc); got != tt.want {
+			t.Errorf("%s: DaytimeBetween() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	from := utc(2023, time.October, 25, 10, 0, 0)
+	tests := []struct {
+		target  time.Time
+		want    [4]int
+		expired bool
+	}{
+		{from.Add(26*time.Hour + 3*time.Minute + 4*time.Second), [4]int{1, 2, 3, 4}, false},
+		{from.Add(90 * time.Second), [4]int{0, 0, 1, 30}, false},
+		{from.Add(999 * time.Millisecond), [4]int{}, false},
+		{from, [4]int{}, true},
+		{from.Add(-time.Hour), [4]int{}, true},
+	}
+	freezeClock(t, from)
+	for _, tt := range tests {
+		d, h, m, s, expired := timefy.CountdownFrom(from, tt.target)
+		if got := [4]int{d, h, m, s}; got != tt.want || expired != tt.expired {
+			t.Errorf("CountdownFrom(%v) = %v, %v; want %v, %v", tt.target, got, expired, tt.want, tt.expired)
+		}
+		d, h, m, s, expired = timefy.Countdown(tt.target)
+		if got := [4]int{d, h, m, s}; got != tt.want || expired != tt.expired {
+			t.Errorf("Countdown(%v) = %v, %v; want %v, %v", tt.target, got, expired, tt.want, tt.expired)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	base := time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		config *timefy.Config
+		v      time.Time
+		want   time.Time
+	}{
+		{"no configuration", nil, base.Add(120), base.Add(120)},
+		{"precision", (&timefy.Config{}).WithPrecision(time.Second), base.Add(999), base},
+		{"location", &timefy.Config{TimeLocation: tokyo}, base, base.In(tokyo)},
+		{"location and precision", (&timefy.Config{TimeLocation: tokyo}).WithPrecision(time.Millisecond), base.In(time.UTC).Add(1500 * time.Microsecond), base.In(tokyo).Add(time.Millisecond)},
+	}
+	for _, tt := range tests {
+		got := (&timefy.Timex{Time: tt.v, Config: tt.config}).Canonical()
+		if got != tt.want {
+			t.Errorf("%s: Canonical() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	// The monotonic reading is stripped, so a canonical wall-clock reading compares equal with ==.
+	wall := time.Now()
+	if got := timefy.With(wall).Canonical(); got != wall.Round(0) {
+		t.Errorf("Canonical() kept the monotonic reading: %v", got)
+	}
+}
+
+func TestFormatAsShort(t *testing.T) {
+	tx := timefy.With(time.Date(2023, time.August, 15, 13, 45, 30, 0, time.UTC))
+	formats := []struct {
+		layout timefy.TimeFormatRFC
+		want   string
+	}{
+		{timefy.TimeFormat20060102150405, "2023-08-15 13:45:30"},
+		{timefy.TimeFormat20060102, "2023-08-15"},
+		{timefy.TimeFormat20060102T150405ZICS, "20230815T134530Z"},
+	}
+	for _, tt := range formats {
+		if got := tx.FormatAs(tt.layout); got != tt.want {
+			t.Errorf("FormatAs(%q) = %q, want %q", tt.layout, got, tt.want)
+		}
+	}
+	shorts := []struct {
+		layout timefy.TimeRFC
+		want   string
+	}{
+		{timefy.TimeRFC01T150405, "13:45:30"},
+		{timefy.TimeRFC07D150405, "13:45"},
+	}
+	for _, tt := range shorts {
+		if got := tx.FormatShort(tt.layout); got != tt.want {
+			t.Errorf("FormatShort(%q) = %q, want %q", tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestToEpoch(t *testing.T) {
+	tx := timefy.With(time.Date(2023, time.August, 15, 13, 45, 30, 123456789, time.UTC))
+	tests := []struct {
+		unit timefy.EpochUnit
+		want int64
+	}{
+		{timefy.EpochSeconds, 1692107130},
+		{timefy.EpochMillis, 1692107130123},
+		{timefy.EpochMicros, 1692107130123456},
+		{timefy.EpochNanos, 1692107130123456789},
+		{timefy.EpochUnit("fortnights"), 1692107130},
+	}
+	for _, tt := range tests {
+		if got := tx.ToEpoch(tt.unit); got != tt.want {
+			t.Errorf("ToEpoch(%q) = %d, want %d", tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestRejectZero(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	freezeClock(t, utc(2023, time.October, 25, 8, 0, 0))
+	tests := []struct {
+		name    string
+		loc     *time.Location
+		reject  bool
+		input   []string
+		wantErr error
+	}{
+		{"zero in UTC", time.UTC, true, []string{"0001-01-01 00:00:00"}, timefy.ErrZeroTime},
+		{"zero in Tokyo", tokyo, true, []string{"0001-01-01 00:00:00"}, timefy.ErrZeroTime},
+		{"no input", tokyo, true, nil, timefy.ErrZeroTime},
+		{"year 1 with a clock", tokyo, true, []string{"0001-01-01 10:00:00"}, nil},
+		{"regular date", tokyo, true, []string{"2023-10-25 00:00:00"}, nil},
+		{"disabled", tokyo, false, []string{"0001-01-01 00:00:00"}, nil},
+	}
+	for _, tt := range tests {
+		config := (&timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: tt.loc}).WithRejectZero(tt.reject)
+		if _, err := config.Parse(tt.input...); err != tt.wantErr {
+			t.Errorf("%s: Parse(%q) error = %v, want %v", tt.name, tt.input, err, tt.wantErr)
+		}
+	}
+	empty := []struct {
+		v    time.Time
+		want bool
+	}{
+		{time.Time{}, true},
+		{time.Time{}.In(tokyo), true},
+		{utc(1, time.January, 1, 0, 0, 1), false},
+		{utc(2023, time.October, 25, 0, 0, 0), false},
+	}
+	for _, tt := range empty {
+		if got := timefy.IsEmpty(tt.v); got != tt.want {
+			t.Errorf("IsEmpty(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestConfigParseInLocation(t *testing.T) {
+	freezeClock(t, utc(2023, time.October, 25, 8, 0, 0))
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	newYork := loadLocation(t, "America/New_York")
+	config := &timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}
+	tests := []struct {
+		loc  *time.Location
+		in   string
+		want time.Time
+	}{
+		{tokyo, "2023-10-25 14:30", time.Date(2023, time.October, 25, 14, 30, 0, 0, tokyo)},
+		{newYork, "2023-10-25 14:30", time.Date(2023, time.October, 25, 14, 30, 0, 0, newYork)},
+		{nil, "2023-10-25 14:30", utc(2023, time.October, 25, 14, 30, 0)},
+		{tokyo, "2023-10-25T14:30:00+07:00", time.Date(2023, time.October, 25, 14, 30, 0, 0, time.FixedZone("", 7*3600))},
+	}
+	for _, tt := range tests {
+		got, err := config.ParseInLocation(tt.loc, tt.in)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseInLocation(%v, %q) = %v, %v, want %v", tt.loc, tt.in, got, err, tt.want)
+		}
+	}
+	a, _ := config.ParseInLocation(tokyo, "2023-10-25 14:30")
+	b, _ := config.ParseInLocation(newYork, "2023-10-25 14:30")
+	if a.Equal(b) {
+		t.Errorf("ParseInLocation in Tokyo and New York both returned %v", a)
+	}
+	if config.TimeLocation != time.UTC {
+		t.Errorf("ParseInLocation changed TimeLocation to %v", config.TimeLocation)
+	}
+}
+
+func TestParseMeridiem(t *testing.T) {
+	freezeClock(t, utc(2023, time.October, 25, 8, 0, 0))
+	config := &timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"3:05 PM", utc(2023, time.October, 25, 15, 5, 0)},
+		{"3:05:09 PM", utc(2023, time.October, 25, 15, 5, 9)},
+		{"3:05 pm", utc(2023, time.October, 25, 15, 5, 0)},
+		{"3:05:09 pm", utc(2023, time.October, 25, 15, 5, 9)},
+		{"3:05 AM", utc(2023, time.October, 25, 3, 5, 0)},
+		{"12:30 am", utc(2023, time.October, 25, 0, 30, 0)},
+		{"3:05PM", utc(2023, time.October, 25, 15, 5, 0)},
+	}
+	for _, tt := range tests {
+		got, err := config.Parse(tt.in)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("Parse(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+		if got := config.MustParse(tt.in); !got.Equal(tt.want) {
+			t.Errorf("MustParse(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	for _, in := range []string{"3:05 Pm", "3:05:09 pM"} {
+		if timefy.TimeOnlyRegexp.MatchString(in) {
+			t.Errorf("TimeOnlyRegexp matches the mixed-case meridiem %q", in)
+		}
+		if v, err := config.Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", in, v)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	freezeClock(t, utc(2023, time.October, 25, 8, 0, 0))
+	tests := []struct {
+		in       string
+		looseOK  bool
+		strictOK bool
+	}{
+		{"15", true, false},
+		{"13:45", true, false},
+		{"13:45:30.000", true, false},
+		{"3:04 PM", true, false},
+		{"2023-10-25 15:04:05", true, true},
+		{"2023-10-25", true, true},
+		{"2023-08", true, true},
+		{"not a time", false, false},
+	}
+	for _, tt := range tests {
+		loose := &timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}
+		strict := (&timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}).WithStrict(true)
+		if _, err := loose.Parse(tt.in); (err == nil) != tt.looseOK {
+			t.Errorf("loose Parse(%q) error = %v, want ok %v", tt.in, err, tt.looseOK)
+		}
+		if _, err := strict.Parse(tt.in); (err == nil) != tt.strictOK {
+			t.Errorf("strict Parse(%q) error = %v, want ok %v", tt.in, err, tt.strictOK)
+		}
+	}
+	strict := (&timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}).WithStrict(true)
+	if got, _ := strict.Parse("2023-10-25 15:04:05"); !got.Equal(utc(2023, time.October, 25, 15, 4, 5)) {
+		t.Errorf("strict Parse of a full timestamp = %v", got)
+	}
+}
+
+func TestNthWeekdayOfMonth(t *testing.T) {
+	tests := []struct {
+		year    int
+		month   time.Month
+		weekday time.Weekday
+		n       int
+		want    time.Time
+		ok      bool
+	}{
+		{2023, time.October, time.Tuesday, 2, utc(2023, time.October, 10, 0, 0, 0), true},
+		{2023, time.October, time.Sunday, 1, utc(2023, time.October, 1, 0, 0, 0), true},
+		{2023, time.October, time.Tuesday, 5, utc(2023, time.October, 31, 0, 0, 0), true},
+		{2023, time.October, time.Wednesday, 5, time.Time{}, false},
+		{2024, time.February, time.Thursday, 5, utc(2024, time.February, 29, 0, 0, 0), true},
+		{2023, time.October, time.Monday, 0, time.Time{}, false},
+		{2023, time.October, time.Monday, 6, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := timefy.NthWeekdayOfMonth(tt.year, tt.month, tt.weekday, tt.n)
+		if ok != tt.ok || (ok && (got.Year() != tt.want.Year() || got.YearDay() != tt.want.YearDay())) {
+			t.Errorf("NthWeekdayOfMonth(%d, %v, %v, %d) = %v, %v, want %v, %v", tt.year, tt.month, tt.weekday, tt.n, got, ok, tt.want, tt.ok)
+		}
+	}
+	last := []struct {
+		year    int
+		month   time.Month
+		weekday time.Weekday
+		want    int
+	}{
+		{2023, time.October, time.Friday, 27},
+		{2023, time.October, time.Tuesday, 31},
+		{2024, time.February, time.Thursday, 29},
+		{2023, time.February, time.Tuesday, 28},
+	}
+	for _, tt := range last {
+		got := timefy.LastWeekdayOfMonth(tt.year, tt.month, tt.weekday)
+		if got.Month() != tt.month || got.Day() != tt.want || got.Weekday() != tt.weekday {
+			t.Errorf("LastWeekdayOfMonth(%d, %v, %v) = %v, want day %d", tt.year, tt.month, tt.weekday, got, tt.want)
+		}
+	}
+}
+
+func TestDayOfYear(t *testing.T) {
+	tests := []struct {
+		v         time.Time
+		day, left int
+	}{
+		{utc(2024, time.January, 1, 0, 0, 0), 1, 365},
+		{utc(2024, time.December, 31, 23, 59, 59), 366, 0},
+		{utc(2024, time.March, 1, 12, 0, 0), 61, 305},
+		{utc(2023, time.January, 1, 0, 0, 0), 1, 364},
+		{utc(2023, time.December, 31, 0, 0, 0), 365, 0},
+		{utc(2023, time.October, 7, 0, 0, 0), 280, 85},
+	}
+	for _, tt := range tests {
+		if got := timefy.DayOfYear(tt.v); got != tt.day {
+			t.Errorf("DayOfYear(%v) = %d, want %d", tt.v, got, tt.day)
+		}
+		if got := timefy.DaysRemainingInYear(tt.v); got != tt.left {
+			t.Errorf("DaysRemainingInYear(%v) = %d, want %d", tt.v, got, tt.left)
+		}
+	}
+}
+
+func TestAtTime(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	base := time.Date(2023, time.October, 25, 17, 30, 15, 500, tokyo)
+	tests := []struct {
+		name                 string
+		hour, min, sec, nsec int
+		want                 time.Time
+	}{
+		{"morning", 9, 0, 0, 0, time.Date(2023, time.October, 25, 9, 0, 0, 0, tokyo)},
+		{"nanoseconds", 23, 59, 59, 999999999, time.Date(2023, time.October, 25, 23, 59, 59, 999999999, tokyo)},
+		{"hour rollover", 25, 0, 0, 0, time.Date(2023, time.October, 26, 1, 0, 0, 0, tokyo)},
+		{"minute rollover", 9, 90, 0, 0, time.Date(2023, time.October, 25, 10, 30, 0, 0, tokyo)},
+		{"negative hour", -1, 0, 0, 0, time.Date(2023, time.October, 24, 23, 0, 0, 0, tokyo)},
+	}
+	for _, tt := range tests {
+		got := timefy.AtTime(base, tt.hour, tt.min, tt.sec, tt.nsec)
+		if !got.Equal(tt.want) || got.Location() != tokyo {
+			t.Errorf("%s: AtTime = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := timefy.With(base).AtTime(tt.hour, tt.min, tt.sec, tt.nsec); !got.Equal(tt.want) {
+			t.Errorf("%s: Timex.AtTime = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	// In America/New_York, clocks jumped from 02:00 to 03:00 on 2023-03-12.
+	newYork := loadLocation(t, "America/New_York")
+	dstDay := time.Date(2023, time.March, 12, 8, 0, 0, 0, newYork)
+	dst := []struct {
+		name                 string
+		hour, min, sec, nsec int
+		want                 time.Time
+	}{
+		{"skipped clock time", 2, 30, 0, 0, time.Date(2023, time.March, 12, 3, 30, 0, 0, newYork)},
+		{"after the transition", 10, 0, 0, 0, time.Date(2023, time.March, 12, 10, 0, 0, 0, newYork)},
+		{"minute rollover", 10, 90, 0, 0, time.Date(2023, time.March, 12, 11, 30, 0, 0, newYork)},
+		{"hour rollover", 25, 0, 0, 0, time.Date(2023, time.March, 13, 1, 0, 0, 0, newYork)},
+		{"second rollover", 9, 0, 120, 0, time.Date(2023, time.March, 12, 9, 2, 0, 0, newYork)},
+		{"nanosecond rollover", 9, 0, 0, 2e9, time.Date(2023, time.March, 12, 9, 0, 2, 0, newYork)},
+		{"negative hour", -1, 0, 0, 0, time.Date(2023, time.March, 11, 23, 0, 0, 0, newYork)},
+	}
+	for _, tt := range dst {
+		if got := timefy.AtTime(dstDay, tt.hour, tt.min, tt.sec, tt.nsec); !got.Equal(tt.want) {
+			t.Errorf("%s: AtTime on a DST day = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMinMaxClamp(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	a := utc(2023, time.October, 25, 10, 0, 0)
+	b := utc(2023, time.October, 24, 10, 0, 0)
+	same := a.In(tokyo)
+	pairs := []struct {
+		a, b, min, max time.Time
+	}{
+		{a, b, b, a},
+		{b, a, b, a},
+		{a, same, a, a},
+	}
+	for _, tt := range pairs {
+		if got := timefy.Min(tt.a, tt.b); got != tt.min {
+			t.Errorf("Min(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.min)
+		}
+		if got := timefy.Max(tt.a, tt.b); got != tt.max {
+			t.Errorf("Max(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.max)
+		}
+	}
+	lo := utc(2023, time.October, 25, 9, 0, 0)
+	hi := utc(2023, time.October, 25, 17, 0, 0)
+	clamps := []struct {
+		name      string
+		v, lo, hi time.Time
+		want      time.Time
+	}{
+		{"below", utc(2023, time.October, 25, 7, 0, 0), lo, hi, lo},
+		{"above", utc(2023, time.October, 25, 20, 0, 0), lo, hi, hi},
+		{"inside", utc(2023, time.October, 25, 12, 0, 0), lo, hi, utc(2023, time.October, 25, 12, 0, 0)},
+		{"at lower bound", lo, lo, hi, lo},
+		{"swapped below", utc(2023, time.October, 25, 7, 0, 0), hi, lo, lo},
+		{"swapped above", utc(2023, time.October, 25, 20, 0, 0), hi, lo, hi},
+		{"swapped inside", utc(2023, time.October, 25, 12, 0, 0), hi, lo, utc(2023, time.October, 25, 12, 0, 0)},
+	}
+	for _, tt := range clamps {
+		if got := timefy.Clamp(tt.v, tt.lo, tt.hi); !got.Equal(tt.want) {
+			t.Errorf("%s: Clamp(%v, %v, %v) = %v, want %v", tt.name, tt.v, tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+func TestEarliestLatest(t *testing.T) {
+	a := utc(2023, time.October, 25, 0, 0, 0)
+	b := utc(2023, time.October, 20, 0, 0, 0)
+	c := utc(2023, time.October, 30, 0, 0, 0)
+	tests := []struct {
+		name             string
+		times            []time.Time
+		earliest, latest time.Time
+		ok               bool
+	}{
+		{"empty", nil, time.Time{}, time.Time{}, false},
+		{"single", []time.Time{a}, a, a, true},
+		{"ordered", []time.Time{b, a, c}, b, c, true},
+		{"out of order", []time.Time{c, b, a}, b, c, true},
+		{"zero value", []time.Time{a, {}, c}, time.Time{}, c, true},
+	}
+	for _, tt := range tests {
+		got, ok := timefy.Earliest(tt.times...)
+		if ok != tt.ok || !got.Equal(tt.earliest) {
+			t.Errorf("%s: Earliest = %v, %v, want %v, %v", tt.name, got, ok, tt.earliest, tt.ok)
+		}
+		got, ok = timefy.Latest(tt.times...)
+		if ok != tt.ok || !got.Equal(tt.latest) {
+			t.Errorf("%s: Latest = %v, %v, want %v, %v", tt.name, got, ok, tt.latest, tt.ok)
+		}
+	}
+}
+
+func TestWeekStartShortcuts(t *testing.T) {
+	v := utc(2023, time.October, 25, 12, 0, 0) // Wednesday
+	monday := (&timefy.Config{WeekStartDay: time.Sunday}).MondayStart()
+	if got := monday.With(v).BeginningOfWeek(); !got.Equal(utc(2023, time.October, 23, 0, 0, 0)) {
+		t.Errorf("MondayStart BeginningOfWeek = %v", got)
+	}
+	sunday := (&timefy.Config{WeekStartDay: time.Monday}).SundayStart()
+	if got := sunday.With(v).BeginningOfWeek(); !got.Equal(utc(2023, time.October, 22, 0, 0, 0)) {
+		t.Errorf("SundayStart BeginningOfWeek = %v", got)
+	}
+	tests := []struct {
+		name    string
+		want    time.Weekday
+		wantErr bool
+	}{
+		{"monday", time.Monday, false},
+		{"Sunday", time.Sunday, false},
+		{"SATURDAY", time.Saturday, false},
+		{" wed ", time.Wednesday, false},
+		{"Fri", time.Friday, false},
+		{"funday", time.Tuesday, true},
+		{"", time.Tuesday, true},
+	}
+	for _, tt := range tests {
+		config := &timefy.Config{WeekStartDay: time.Tuesday}
+		got, err := config.WithWeekStartDayByName(tt.name)
+		if (err != nil) != tt.wantErr || got != config || config.WeekStartDay != tt.want {
+			t.Errorf("WithWeekStartDayByName(%q) = %v, %v, want %v, error %v", tt.name, config.WeekStartDay, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAddDayCalendar(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	tests := []struct {
+		name       string
+		v          time.Time
+		day        int
+		want       time.Time
+		wantAddDay time.Time
+	}{
+		{"spring forward",
+			time.Date(2023, time.March, 11, 10, 0, 0, 0, newYork), 1,
+			time.Date(2023, time.March, 12, 10, 0, 0, 0, newYork),
+			time.Date(2023, time.March, 12, 11, 0, 0, 0, newYork)},
+		{"fall back",
+			time.Date(2023, time.November, 4, 10, 0, 0, 0, newYork), 1,
+			time.Date(2023, time.November, 5, 10, 0, 0, 0, newYork),
+			time.Date(2023, time.November, 5, 9, 0, 0, 0, newYork)},
+		{"backwards over spring forward",
+			time.Date(2023, time.March, 13, 10, 0, 0, 0, newYork), -2,
+			time.Date(2023, time.March, 11, 10, 0, 0, 0, newYork),
+			time.Date(2023, time.March, 11, 9, 0, 0, 0, newYork)},
+		{"zero days",
+			time.Date(2023, time.March, 12, 10, 0, 0, 0, newYork), 0,
+			time.Date(2023, time.March, 12, 10, 0, 0, 0, newYork),
+			time.Date(2023, time.March, 12, 10, 0, 0, 0, newYork)},
+		{"utc",
+			utc(2023, time.October, 25, 10, 0, 0), 7,
+			utc(2023, time.November, 1, 10, 0, 0),
+			utc(2023, time.November, 1, 10, 0, 0)},
+	}
+	for _, tt := range tests {
+		got := timefy.AddDayCalendar(tt.v, tt.day)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: AddDayCalendar(%v, %d) = %v, want %v", tt.name, tt.v, tt.day, got, tt.want)
+		}
+		if got.Hour() != tt.v.Hour() || got.Minute() != tt.v.Minute() {
+			t.Errorf("%s: AddDayCalendar(%v, %d) = %v, clock time not preserved", tt.name, tt.v, tt.day, got)
+		}
+		if got := timefy.AddDay(tt.v, tt.day); !got.Equal(tt.wantAddDay) {
+			t.Errorf("%s: AddDay(%v, %d) = %v, want %v", tt.name, tt.v, tt.day, got, tt.wantAddDay)
+		}
+	}
+	if got := timefy.AddDayCalendar(time.Date(2023, time.March, 11, 10, 0, 0, 0, newYork), 1).Sub(time.Date(2023, time.March, 11, 10, 0, 0, 0, newYork)); got != 23*time.Hour {
+		t.Errorf("AddDayCalendar over spring forward elapsed %v, want 23h", got)
+	}
+}
+
+func TestParseFlexible(t *testing.T) {
+	freezeClock(t, utc(2023, time.October, 25, 8, 0, 0))
+	config := &timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{" 2023-10-25T14:30:00Z\n", utc(2023, time.October, 25, 14, 30, 0), false},
+		{"\t2023-10-25 14:30:00  ", utc(2023, time.October, 25, 14, 30, 0), false},
+		{"2023.10.25,14:30", utc(2023, time.October, 25, 14, 30, 0), false},
+		{"2023/10/25, 14:30:15", utc(2023, time.October, 25, 14, 30, 15), false},
+		{"2023.10.25   14:30", utc(2023, time.October, 25, 14, 30, 0), false},
+		{"2023/10/25", utc(2023, time.October, 25, 0, 0, 0), false},
+		{"2023-10-25 14:30:00.500", time.Date(2023, time.October, 25, 14, 30, 0, 500000000, time.UTC), false},
+		{"not a date, really", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := config.ParseFlexible(tt.in)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && !got.Equal(tt.want)) {
+			t.Errorf("ParseFlexible(%q) = %v, %v, want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestTruncateRoundT(t *testing.T) {
+	config := &timefy.Config{WeekStartDay: time.Monday, TimeLocation: time.UTC}
+	v := config.With(utc(2023, time.October, 29, 10, 42, 0)) // Sunday
+	tests := []struct {
+		name string
+		got  *timefy.Timex
+		want time.Time
+	}{
+		{"truncate hour", v.TruncateT(time.Hour), utc(2023, time.October, 29, 10, 0, 0)},
+		{"round hour", v.RoundT(time.Hour), utc(2023, time.October, 29, 11, 0, 0)},
+		{"truncate quarter", v.TruncateT(15 * time.Minute), utc(2023, time.October, 29, 10, 30, 0)},
+		{"round quarter", v.RoundT(15 * time.Minute), utc(2023, time.October, 29, 10, 45, 0)},
+		{"non-positive", v.TruncateT(0), utc(2023, time.October, 29, 10, 42, 0)},
+	}
+	for _, tt := range tests {
+		if !tt.got.Time.Equal(tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got.Time, tt.want)
+		}
+		if tt.got.Config != config {
+			t.Errorf("%s lost the configuration", tt.name)
+		}
+	}
+	if got := v.TruncateT(time.Hour).BeginningOfWeek(); !got.Equal(utc(2023, time.October, 23, 0, 0, 0)) {
+		t.Errorf("TruncateT(time.Hour).BeginningOfWeek() = %v, want the Monday start of the configuration", got)
+	}
+	if got := v.RoundT(time.Hour).EndOfWeek(); !got.Equal(time.Date(2023, time.October, 29, 23, 59, 59, 999999999, time.UTC)) {
+		t.Errorf("RoundT(time.Hour).EndOfWeek() = %v", got)
+	}
+}
+
+func TestIsTodayYesterdayTomorrow(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	tests := []struct {
+		name                       string
+		now, v                     time.Time
+		today, yesterday, tomorrow bool
+	}{
+		{"same day", utc(2023, time.October, 25, 0, 0, 0), utc(2023, time.October, 25, 23, 59, 59), true, false, false},
+		{"before midnight", utc(2023, time.October, 25, 0, 0, 0), utc(2023, time.October, 24, 23, 59, 59), false, true, false},
+		{"after midnight", utc(2023, time.October, 25, 23, 59, 59), utc(2023, time.October, 26, 0, 0, 0), false, false, true},
+		{"two days ago", utc(2023, time.October, 25, 12, 0, 0), utc(2023, time.October, 23, 12, 0, 0), false, false, false},
+		{"month boundary", utc(2023, time.November, 1, 0, 30, 0), utc(2023, time.October, 31, 23, 0, 0), false, true, false},
+		{"year boundary", utc(2023, time.December, 31, 23, 30, 0), utc(2024, time.January, 1, 0, 0, 0), false, false, true},
+		// 2023-10-25 20:00 UTC is 2023-10-26 05:00 in Tokyo.
+		{"location of v", utc(2023, time.October, 25, 20, 0, 0), time.Date(2023, time.October, 26, 1, 0, 0, 0, tokyo), true, false, false},
+		{"yesterday in Tokyo", utc(2023, time.October, 25, 20, 0, 0), time.Date(2023, time.October, 25, 23, 0, 0, 0, tokyo), false, true, false},
+	}
+	for _, tt := range tests {
+		freezeClock(t, tt.now)
+		if got := timefy.IsToday(tt.v); got != tt.today {
+			t.Errorf("%s: IsToday(%v) = %v, want %v", tt.name, tt.v, got, tt.today)
+		}
+		if got := timefy.IsYesterday(tt.v); got != tt.yesterday {
+			t.Errorf("%s: IsYesterday(%v) = %v, want %v", tt.name, tt.v, got, tt.yesterday)
+		}
+		if got := timefy.IsTomorrow(tt.v); got != tt.tomorrow {
+			t.Errorf("%s: IsTomorrow(%v) = %v, want %v", tt.name, tt.v, got, tt.tomorrow)
+		}
+	}
+}
+
+func TestCalendarLabel(t *testing.T) {
+	freezeClock(t, utc(2023, time.October, 25, 10, 0, 0)) // Wednesday
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	custom := (&timefy.Config{}).WithCalendarLayouts("3:04 PM", "Jan 2, 2006").WithCalendarWeekdaySpan(2)
+	tests := []struct {
+		name   string
+		config *timefy.Config
+		v      time.Time
+		want   string
+	}{
+		{"today", nil, utc(2023, time.October, 25, 14, 30, 0), "Today at 14:30"},
+		{"today at midnight", nil, utc(2023, time.October, 25, 0, 0, 0), "Today at 00:00"},
+		{"yesterday", nil, utc(2023, time.October, 24, 9, 15, 0), "Yesterday at 09:15"},
+		{"tomorrow", nil, utc(2023, time.October, 26, 23, 59, 0), "Tomorrow at 23:59"},
+		{"last week", nil, utc(2023, time.October, 23, 8, 0, 0), "Mon at 08:00"},
+		{"six days ago", nil, utc(2023, time.October, 19, 8, 0, 0), "Thu at 08:00"},
+		{"seven days ago", nil, utc(2023, time.October, 18, 8, 0, 0), "2023-10-18"},
+		{"next week", nil, utc(2023, time.October, 31, 8, 0, 0), "Tue at 08:00"},
+		{"far future", nil, utc(2023, time.November, 5, 8, 0, 0), "2023-11-05"},
+		// 2023-10-26 01:00 in Tokyo is 2023-10-25 16:00 UTC, but the current day in Tokyo is still 2023-10-25.
+		{"location of the time", nil, time.Date(2023, time.October, 26, 1, 0, 0, 0, tokyo), "Tomorrow at 01:00"},
+		{"custom today", custom, utc(2023, time.October, 25, 14, 30, 0), "Today at 2:30 PM"},
+		{"custom weekday", custom, utc(2023, time.October, 23, 8, 0, 0), "Mon at 8:00 AM"},
+		{"custom span", custom, utc(2023, time.October, 22, 8, 0, 0), "Oct 22, 2023"},
+	}
+	for _, tt := range tests {
+		v := timefy.With(tt.v)
+		if tt.config != nil {
+			v = tt.config.With(tt.v)
+		}
+		if got := v.CalendarLabel(); got != tt.want {
+			t.Errorf("%s: CalendarLabel(%v) = %q, want %q", tt.name, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestQuarterHalfOf(t *testing.T) {
+	tests := []struct {
+		month         time.Month
+		quarter, half uint
+	}{
+		{time.January, 1, 1}, {time.February, 1, 1}, {time.March, 1, 1},
+		{time.April, 2, 1}, {time.May, 2, 1}, {time.June, 2, 1},
+		{time.July, 3, 2}, {time.August, 3, 2}, {time.September, 3, 2},
+		{time.October, 4, 2}, {time.November, 4, 2}, {time.December, 4, 2},
+	}
+	for _, tt := range tests {
+		for _, v := range []time.Time{utc(1999, tt.month, 1, 0, 0, 0), utc(2023, tt.month, 28, 23, 59, 59)} {
+			if got := timefy.QuarterOf(v); got != tt.quarter {
+				t.Errorf("QuarterOf(%v) = %d, want %d", v, got, tt.quarter)
+			}
+			if got := timefy.HalfOf(v); got != tt.half {
+				t.Errorf("HalfOf(%v) = %d, want %d", v, got, tt.half)
+			}
+		}
+	}
+}
+
+func TestEndOfHalf(t *testing.T) {
+	tests := []struct {
+		v        time.Time
+		want     time.Time
+		nextHalf uint
+	}{
+		{utc(2023, time.January, 1, 0, 0, 0), time.Date(2023, time.June, 30, 23, 59, 59, 999999999, time.UTC), 2},
+		{utc(2023, time.June, 30, 23, 59, 59), time.Date(2023, time.June, 30, 23, 59, 59, 999999999, time.UTC), 2},
+		{utc(2023, time.July, 1, 0, 0, 0), time.Date(2023, time.December, 31, 23, 59, 59, 999999999, time.UTC), 1},
+		{utc(2024, time.October, 25, 12, 0, 0), time.Date(2024, time.December, 31, 23, 59, 59, 999999999, time.UTC), 1},
+	}
+	for _, tt := range tests {
+		got := timefy.With(tt.v).EndOfHalf()
+		if !got.Equal(tt.want) {
+			t.Errorf("EndOfHalf(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+		next := got.Add(time.Nanosecond)
+		if timefy.HalfOf(next) != tt.nextHalf || !next.Equal(timefy.With(next).BeginningOfHalf()) {
+			t.Errorf("EndOfHalf(%v).Add(time.Nanosecond) = %v, want the beginning of half %d", tt.v, next, tt.nextHalf)
+		}
+	}
+}
+
+func TestPreviousNextNavigation(t *testing.T) {
+	config := &timefy.Config{WeekStartDay: time.Monday, TimeLocation: time.UTC}
+	tests := []struct {
+		name string
+		got  *timefy.Timex
+		want time.Time
+	}{
+		{"previous month", config.With(utc(2023, time.October, 25, 14, 30, 0)).PreviousMonth(), utc(2023, time.September, 25, 14, 30, 0)},
+		{"next month", config.With(utc(2023, time.October, 25, 14, 30, 0)).NextMonth(), utc(2023, time.November, 25, 14, 30, 0)},
+		{"previous month clamped", config.With(utc(2023, time.March, 31, 9, 0, 0)).PreviousMonth(), utc(2023, time.February, 28, 9, 0, 0)},
+		{"next month clamped", config.With(utc(2024, time.January, 31, 9, 0, 0)).NextMonth(), utc(2024, time.February, 29, 9, 0, 0)},
+		{"previous month across years", config.With(utc(2024, time.January, 15, 9, 0, 0)).PreviousMonth(), utc(2023, time.December, 15, 9, 0, 0)},
+		{"previous week", config.With(utc(2023, time.October, 25, 14, 30, 0)).PreviousWeek(), utc(2023, time.October, 18, 14, 30, 0)},
+		{"next week across years", config.With(utc(2023, time.December, 28, 14, 30, 0)).NextWeek(), utc(2024, time.January, 4, 14, 30, 0)},
+	}
+	for _, tt := range tests {
+		if !tt.got.Time.Equal(tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got.Time, tt.want)
+		}
+		if tt.got.Config != config {
+			t.Errorf("%s lost the configuration", tt.name)
+		}
+	}
+	chains := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"PreviousMonth().BeginningOfMonth()", config.With(utc(2024, time.January, 15, 9, 0, 0)).PreviousMonth().BeginningOfMonth(), utc(2023, time.December, 1, 0, 0, 0)},
+		{"NextMonth().BeginningOfMonth()", config.With(utc(2023, time.December, 31, 9, 0, 0)).NextMonth().BeginningOfMonth(), utc(2024, time.January, 1, 0, 0, 0)},
+		{"NextWeek().BeginningOfWeek()", config.With(utc(2023, time.December, 28, 9, 0, 0)).NextWeek().BeginningOfWeek(), utc(2024, time.January, 1, 0, 0, 0)},
+		{"PreviousWeek().BeginningOfWeek()", config.With(utc(2024, time.January, 3, 9, 0, 0)).PreviousWeek().BeginningOfWeek(), utc(2023, time.December, 25, 0, 0, 0)},
+	}
+	for _, tt := range chains {
+		if !tt.got.Equal(tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestConvertAll(t *testing.T) {
+	input := []time.Time{utc(2023, time.October, 25, 0, 0, 0), utc(2023, time.October, 25, 20, 0, 0)}
+	got, err := timefy.ConvertAll(input, "Asia/Tokyo")
+	if err != nil || len(got) != len(input) {
+		t.Fatalf("ConvertAll(Asia/Tokyo) = %v, %v", got, err)
+	}
+	wantHours := []int{9, 5}
+	for i, v := range got {
+		if !v.Equal(input[i]) || v.Location().String() != "Asia/Tokyo" || v.Hour() != wantHours[i] {
+			t.Errorf("ConvertAll(Asia/Tokyo)[%d] = %v, want %v in Asia/Tokyo", i, v, input[i])
+		}
+		if input[i].Location() != time.UTC {
+			t.Errorf("ConvertAll modified the input at %d: %v", i, input[i])
+		}
+	}
+	if got, err := timefy.ConvertAll(input, "Mars/Olympus"); err == nil || got != nil {
+		t.Errorf("ConvertAll(Mars/Olympus) = %v, %v, want nil and an error", got, err)
+	}
+	if got, err := timefy.ConvertAll(nil, "UTC"); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("ConvertAll(nil, UTC) = %v, %v, want an empty slice", got, err)
+	}
+}
+
+func TestParseWithHint(t *testing.T) {
+	config := &timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}
+	tests := []struct {
+		hint    string
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{"02/01/2006", "01/02/2023", utc(2023, time.February, 1, 0, 0, 0), false},
+		{"", "01/02/2023", utc(2023, time.January, 2, 0, 0, 0), false},
+		{"02/01/2006", "2023-10-25 14:30:00", utc(2023, time.October, 25, 14, 30, 0), false},
+		{"02.01.2006 15:04", "25.10.2023 14:30", utc(2023, time.October, 25, 14, 30, 0), false},
+		{"02/01/2006", "not a date", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := config.ParseWithHint(tt.hint, tt.in)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && !got.Equal(tt.want)) {
+			t.Errorf("ParseWithHint(%q, %q) = %v, %v, want %v, error %v", tt.hint, tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if got, _ := config.Parse("01/02/2023"); !got.Equal(utc(2023, time.January, 2, 0, 0, 0)) {
+		t.Errorf("Parse(01/02/2023) = %v, want the default month-first order", got)
+	}
+	if len(config.TimeFormats) != len(timefy.TimeFormats) {
+		t.Errorf("ParseWithHint changed the configured formats")
+	}
+}
+
+func TestEndOfDayPrecise(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	lastHalfSecond := time.Date(2023, time.October, 25, 23, 59, 59, 500000000, time.UTC)
+	tests := []struct {
+		v    time.Time
+		want time.Time
+	}{
+		{utc(2023, time.October, 25, 10, 0, 0), time.Date(2023, time.October, 25, 23, 59, 59, 999999999, time.UTC)},
+		{lastHalfSecond, time.Date(2023, time.October, 25, 23, 59, 59, 999999999, time.UTC)},
+		{time.Date(2023, time.October, 25, 1, 0, 0, 0, tokyo), time.Date(2023, time.October, 25, 23, 59, 59, 999999999, tokyo)},
+	}
+	for _, tt := range tests {
+		got := timefy.EndOfDayPrecise(tt.v)
+		if !got.Equal(tt.want) || got.Location() != tt.v.Location() {
+			t.Errorf("EndOfDayPrecise(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+		if got := timefy.With(tt.v).EndOfDay(); !got.Equal(tt.want) {
+			t.Errorf("Timex.EndOfDay(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+	if lastHalfSecond.After(timefy.EndOfDayPrecise(lastHalfSecond)) {
+		t.Errorf("23:59:59.5 is after EndOfDayPrecise")
+	}
+	ends := []struct {
+		name      string
+		inclusive bool
+		end       func(*timefy.Timex) time.Time
+		want      time.Time
+	}{
+		{"inclusive day", true, (*timefy.Timex).EndOfDay, time.Date(2023, time.October, 25, 23, 59, 59, 999999999, time.UTC)},
+		{"truncated day", false, (*timefy.Timex).EndOfDay, utc(2023, time.October, 25, 23, 59, 59)},
+		{"inclusive month", true, (*timefy.Timex).EndOfMonth, time.Date(2023, time.October, 31, 23, 59, 59, 999999999, time.UTC)},
+		{"truncated month", false, (*timefy.Timex).EndOfMonth, utc(2023, time.October, 31, 23, 59, 59)},
+		{"truncated hour", false, (*timefy.Timex).EndOfHour, utc(2023, time.October, 25, 23, 59, 59)},
+		{"truncated year", false, (*timefy.Timex).EndOfYear, utc(2023, time.December, 31, 23, 59, 59)},
+	}
+	for _, tt := range ends {
+		config := (&timefy.Config{WeekStartDay: time.Monday, TimeLocation: time.UTC}).WithInclusiveEnd(tt.inclusive)
+		got := tt.end(config.With(lastHalfSecond))
+		if !got.Equal(tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+		if timefy.IsSameDay(got, lastHalfSecond) && got.Before(lastHalfSecond) == tt.inclusive {
+			t.Errorf("%s: 23:59:59.5 before the end = %v, want %v", tt.name, !got.Before(lastHalfSecond), tt.inclusive)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	freezeClock(t, utc(2023, time.October, 25, 8, 0, 0))
+	newYork := loadLocation(t, "America/New_York")
+	tests := []struct {
+		loc  *time.Location
+		in   string
+		want time.Time
+	}{
+		{time.UTC, "2023-10-25", utc(2023, time.October, 25, 0, 0, 0)},
+		{time.UTC, "2023-10-25 14:30:45", utc(2023, time.October, 25, 0, 0, 0)},
+		{time.UTC, "2023-10-25T23:59:59.999Z", utc(2023, time.October, 25, 0, 0, 0)},
+		{newYork, "2023-10-25", time.Date(2023, time.October, 25, 0, 0, 0, 0, newYork)},
+		{newYork, "2023-10-25 14:30:45", time.Date(2023, time.October, 25, 0, 0, 0, 0, newYork)},
+		{newYork, "2023-11-05 14:30", time.Date(2023, time.November, 5, 0, 0, 0, 0, newYork)},
+	}
+	for _, tt := range tests {
+		config := &timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: tt.loc}
+		got, err := config.ParseDate(tt.in)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseDate(%q) in %v = %v, %v, want %v", tt.in, tt.loc, got, err, tt.want)
+		}
+	}
+	config := &timefy.Config{TimeFormats: timefy.TimeFormats, TimeLocation: time.UTC}
+	if _, err := config.ParseDate("not a date"); err == nil {
+		t.Errorf("ParseDate(not a date) returned no error")
+	}
+}
+
+func TestIterateRange(t *testing.T) {
+	start := utc(2023, time.October, 25, 9, 0, 0)
+	tests := []struct {
+		name  string
+		end   time.Time
+		step  time.Duration
+		limit int
+		want  []time.Time
+	}{
+		{"inclusive end", start.Add(time.Hour), 30 * time.Minute, -1,
+			[]time.Time{start, start.Add(30 * time.Minute), start.Add(time.Hour)}},
+		{"end between steps", start.Add(50 * time.Minute), 30 * time.Minute, -1,
+			[]time.Time{start, start.Add(30 * time.Minute)}},
+		{"early stop", start.Add(10 * time.Hour), time.Hour, 2,
+			[]time.Time{start, start.Add(time.Hour)}},
+		{"end before start", start.Add(-time.Hour), time.Hour, -1, nil},
+		{"non-positive step", start.Add(time.Hour), 0, -1, nil},
+	}
+	for _, tt := range tests {
+		var got []time.Time
+		timefy.IterateRange(start, tt.end, tt.step, func(v time.Time) bool {
+			got = append(got, v)
+			return tt.limit < 0 || len(got) < tt.limit
+		})
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: IterateRange visited %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIterateDays(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	at := func(m time.Month, d, h, mi int) time.Time { return time.Date(2023, m, d, h, mi, 0, 0, newYork) }
+	tests := []struct {
+		name       string
+		start, end time.Time
+		limit      int
+		want       []time.Time
+	}{
+		{"spring forward", at(time.March, 11, 10, 0), at(time.March, 13, 10, 0), -1,
+			[]time.Time{at(time.March, 11, 10, 0), at(time.March, 12, 10, 0), at(time.March, 13, 10, 0)}},
+		{"fall back", at(time.November, 4, 10, 0), at(time.November, 6, 10, 0), -1,
+			[]time.Time{at(time.November, 4, 10, 0), at(time.November, 5, 10, 0), at(time.November, 6, 10, 0)}},
+		// 02:30 does not exist on 2023-03-12 and is normalized by time.Date there; the following days keep 02:30.
+		{"clock time in the DST gap", at(time.March, 11, 2, 30), at(time.March, 13, 23, 0), -1,
+			[]time.Time{at(time.March, 11, 2, 30), at(time.March, 12, 2, 30), at(time.March, 13, 2, 30)}},
+		{"end before the clock time", at(time.March, 11, 10, 0), at(time.March, 13, 9, 0), -1,
+			[]time.Time{at(time.March, 11, 10, 0), at(time.March, 12, 10, 0)}},
+		{"early stop", at(time.March, 11, 10, 0), at(time.March, 31, 10, 0), 2,
+			[]time.Time{at(time.March, 11, 10, 0), at(time.March, 12, 10, 0)}},
+		{"end before start", at(time.March, 11, 10, 0), at(time.March, 10, 10, 0), -1, nil},
+	}
+	for _, tt := range tests {
+		var got []time.Time
+		timefy.IterateDays(tt.start, tt.end, func(v time.Time) bool {
+			got = append(got, v)
+			return tt.limit < 0 || len(got) < tt.limit
+		})
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: IterateDays visited %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	var steps []time.Duration
+	prev := at(time.March, 11, 10, 0)
+	timefy.IterateDays(prev, at(time.March, 13, 10, 0), func(v time.Time) bool {
+		if v != prev {
+			steps = append(steps, v.Sub(prev))
+		}
+		prev = v
+		return true
+	})
+	if fmt.Sprint(steps) != fmt.Sprint([]time.Duration{23 * time.Hour, 24 * time.Hour}) {
+		t.Errorf("IterateDays steps over spring forward = %v, want [23h 24h]", steps)
+	}
+}
+
+func TestDiffFrom(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	tests := []struct {
+		name     string
+		t, other time.Time
+		parts    [6]int
+		negative bool
+	}{
+		{"past", utc(2023, time.July, 25, 10, 30, 15), utc(2020, time.May, 20, 8, 0, 0), [6]int{3, 2, 5, 2, 30, 15}, false},
+		{"future", utc(2020, time.May, 20, 8, 0, 0), utc(2023, time.July, 25, 10, 30, 15), [6]int{3, 2, 5, 2, 30, 15}, true},
+		{"borrowed day", utc(2023, time.March, 1, 0, 0, 0), utc(2023, time.January, 31, 10, 0, 0), [6]int{0, 1, 0, 14, 0, 0}, false},
+		{"borrowed minutes", utc(2023, time.October, 26, 1, 10, 0), utc(2023, time.October, 25, 23, 50, 30), [6]int{0, 0, 0, 1, 19, 30}, false},
+		{"other location", utc(2023, time.October, 25, 12, 0, 0), time.Date(2023, time.October, 25, 9, 0, 0, 0, tokyo), [6]int{0, 0, 0, 12, 0, 0}, false},
+		{"equal", utc(2023, time.October, 25, 12, 0, 0), utc(2023, time.October, 25, 12, 0, 0), [6]int{}, false},
+	}
+	for _, tt := range tests {
+		diff := timefy.With(tt.t).DiffFrom(tt.other)
+		if diff.Duration != tt.t.Sub(tt.other) {
+			t.Errorf("%s: Duration = %v, want %v", tt.name, diff.Duration, tt.t.Sub(tt.other))
+		}
+		if got := [6]int{diff.Years, diff.Months, diff.Days, diff.Hours, diff.Minutes, diff.Seconds}; got != tt.parts {
+			t.Errorf("%s: parts = %v, want %v", tt.name, got, tt.parts)
+		}
+		if diff.Negative != tt.negative {
+			t.Errorf("%s: Negative = %v, want %v", tt.name, diff.Negative, tt.negative)
+		}
+	}
+}
+
+func TestWeekdayMonthNames(t *testing.T) {
+	freezeClock(t, utc(2023, time.October, 25, 10, 0, 0)) // Wednesday
+	french := (&timefy.Config{}).
+		WithWeekdayNames([7]string{"Dimanche", "Lundi", "Mardi", "Mercredi", "Jeudi", "Vendredi", "Samedi"}).
+		WithMonthNames([12]string{"Janvier", "Février", "Mars", "Avril", "Mai", "Juin",
+			"Juillet", "Août", "Septembre", "Octobre", "Novembre", "Décembre"})
+	partial := (&timefy.Config{}).WithWeekdayNames([7]string{1: "Lundi"}).WithMonthNames([12]string{0: "Janvier"})
+	tests := []struct {
+		name           string
+		config         *timefy.Config
+		v              time.Time
+		weekday, month string
+		label          string
+	}{
+		{"french", french, utc(2023, time.October, 23, 8, 0, 0), "Lundi", "Octobre", "Lundi at 08:00"},
+		{"french sunday", french, utc(2023, time.January, 1, 8, 0, 0), "Dimanche", "Janvier", "2023-01-01"},
+		{"french december", french, utc(2023, time.December, 31, 8, 0, 0), "Dimanche", "Décembre", "2023-12-31"},
+		{"partial set", partial, utc(2023, time.October, 23, 8, 0, 0), "Lundi", "October", "Lundi at 08:00"},
+		{"partial unset", partial, utc(2023, time.October, 27, 8, 0, 0), "Friday", "October", "Fri at 08:00"},
+		{"english", &timefy.Config{}, utc(2023, time.October, 23, 8, 0, 0), "Monday", "October", "Mon at 08:00"},
+	}
+	for _, tt := range tests {
+		v := tt.config.With(tt.v)
+		if got := v.WeekdayName(); got != tt.weekday {
+			t.Errorf("%s: WeekdayName() = %q, want %q", tt.name, got, tt.weekday)
+		}
+		if got := v.MonthName(); got != tt.month {
+			t.Errorf("%s: MonthName() = %q, want %q", tt.name, got, tt.month)
+		}
+		if got := v.CalendarLabel(); got != tt.label {
+			t.Errorf("%s: CalendarLabel() = %q, want %q", tt.name, got, tt.label)
+		}
+	}
+}
+
+func TestNextPreviousBusinessDay(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	christmas := utc(2023, time.December, 25, 0, 0, 0)
+	boxingDay := utc(2023, time.December, 26, 0, 0, 0)
+	tests := []struct {
+		name       string
+		v          time.Time
+		holidays   []time.Time
+		next, prev time.Time
+	}{
+		{"saturday", utc(2023, time.October, 28, 9, 0, 0), nil, utc(2023, time.October, 30, 9, 0, 0), utc(2023, time.October, 27, 9, 0, 0)},
+		{"sunday", utc(2023, time.October, 29, 9, 0, 0), nil, utc(2023, time.October, 30, 9, 0, 0), utc(2023, time.October, 27, 9, 0, 0)},
+		{"friday", utc(2023, time.October, 27, 9, 0, 0), nil, utc(2023, time.October, 30, 9, 0, 0), utc(2023, time.October, 26, 9, 0, 0)},
+		{"monday", utc(2023, time.October, 30, 9, 0, 0), nil, utc(2023, time.October, 31, 9, 0, 0), utc(2023, time.October, 27, 9, 0, 0)},
+		{"saturday before christmas", utc(2023, time.December, 23, 9, 0, 0), []time.Time{christmas, boxingDay}, utc(2023, time.December, 27, 9, 0, 0), utc(2023, time.December, 22, 9, 0, 0)},
+		{"sunday after holiday friday", utc(2023, time.December, 31, 9, 0, 0), []time.Time{utc(2023, time.December, 29, 0, 0, 0)}, utc(2024, time.January, 1, 9, 0, 0), utc(2023, time.December, 28, 9, 0, 0)},
+		{"wednesday after christmas", utc(2023, time.December, 27, 9, 0, 0), []time.Time{christmas, boxingDay}, utc(2023, time.December, 28, 9, 0, 0), utc(2023, time.December, 22, 9, 0, 0)},
+		// The clock time is kept across the fall back transition of 2023-11-05.
+		{"saturday over DST", time.Date(2023, time.November, 4, 9, 0, 0, 0, newYork), nil, time.Date(2023, time.November, 6, 9, 0, 0, 0, newYork), time.Date(2023, time.November, 3, 9, 0, 0, 0, newYork)},
+	}
+	for _, tt := range tests {
+		if tt.holidays == nil {
+			if got := timefy.NextBusinessDay(tt.v); !got.Equal(tt.next) {
+				t.Errorf("%s: NextBusinessDay(%v) = %v, want %v", tt.name, tt.v, got, tt.next)
+			}
+			if got := timefy.PreviousBusinessDay(tt.v); !got.Equal(tt.prev) {
+				t.Errorf("%s: PreviousBusinessDay(%v) = %v, want %v", tt.name, tt.v, got, tt.prev)
+			}
+		}
+		if got := timefy.NextBusinessDayWithHolidays(tt.v, tt.holidays); !got.Equal(tt.next) {
+			t.Errorf("%s: NextBusinessDayWithHolidays(%v) = %v, want %v", tt.name, tt.v, got, tt.next)
+		}
+		if got := timefy.PreviousBusinessDayWithHolidays(tt.v, tt.holidays); !got.Equal(tt.prev) {
+			t.Errorf("%s: PreviousBusinessDayWithHolidays(%v) = %v, want %v", tt.name, tt.v, got, tt.prev)
+		}
+	}
+}
+
+func TestConfigJSON(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	config := (&timefy.Config{
+		WeekStartDay: time.Monday,
+		TimeLocation: tokyo,
+		TimeFormats:  []string{"2006-01-02", "02/01/2006"},
+		Precision:    time.Minute,
+	}).WithExactSeconds(true).WithStrict(true).WithRejectZero(true).WithInclusiveEnd(false).
+		WithCalendarLayouts("3:04 PM", "Jan 2, 2006").WithCalendarWeekdaySpan(3).
+		WithWeekdayNames([7]string{"Dimanche", "Lundi", "Mardi", "Mercredi", "Jeudi", "Vendredi", "Samedi"}).
+		WithMonthNames([12]string{11: "Décembre"})
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal(Config) error = %v", err)
+	}
+	for _, want := range []string{`"week_start_day":"Monday"`, `"time_location":"Asia/Tokyo"`, `"weekday_names":["Dimanche"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("json.Marshal(Config) = %s, want it to contain %s", data, want)
+		}
+	}
+	var decoded timefy.Config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", data, err)
+	}
+	if decoded.TimeLocation == nil || decoded.TimeLocation.String() != "Asia/Tokyo" {
+		t.Errorf("decoded TimeLocation = %v, want Asia/Tokyo", decoded.TimeLocation)
+	}
+	decodedTokyo := decoded.TimeLocation
+	decoded.TimeLocation = config.TimeLocation
+	if fmt.Sprintf("%+v", decoded) != fmt.Sprintf("%+v", *config) {
+		t.Errorf("round trip = %+v, want %+v", decoded, *config)
+	}
+	decoded.TimeLocation = decodedTokyo
+	v := decoded.MustParse("2023-10-25")
+	if want := time.Date(2023, time.October, 25, 0, 0, 0, 0, tokyo); !v.Equal(want) {
+		t.Errorf("decoded MustParse(2023-10-25) = %v, want %v", v, want)
+	}
+	if data, _ := json.Marshal(&timefy.Config{}); strings.Contains(string(data), "time_location") || strings.Contains(string(data), "weekday_names") || strings.Contains(string(data), "month_names") {
+		t.Errorf("json.Marshal(empty Config) = %s, want no location or names", data)
+	}
+	inputs := []struct {
+		in      string
+		day     time.Weekday
+		loc     string
+		wantErr bool
+	}{
+		{`{"week_start_day":"sunday","time_location":"America/New_York"}`, time.Sunday, "America/New_York", false},
+		{`{"week_start_day":1,"time_location":"UTC"}`, time.Monday, "UTC", false},
+		{`{"time_location":"Mars/Olympus"}`, time.Saturday, "", true},
+		{`{"week_start_day":"funday"}`, time.Saturday, "", true},
+		{`{"week_start_day":9}`, time.Saturday, "", true},
+		{`{"weekday_names":["a","b","c","d","e","f","g","h"]}`, time.Saturday, "", true},
+	}
+	for _, tt := range inputs {
+		decoded := timefy.Config{WeekStartDay: time.Saturday}
+		err := json.Unmarshal([]byte(tt.in), &decoded)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("json.Unmarshal(%s) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (decoded.WeekStartDay != tt.day || decoded.TimeLocation.String() != tt.loc) {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v, %s", tt.in, decoded.WeekStartDay, decoded.TimeLocation, tt.day, tt.loc)
+		}
+	}
+}
+
+func TestParseMulti(t *testing.T) {
+	freezeClock(t, utc(2023, time.October, 25, 8, 0, 0))
+	config := &timefy.Config{TimeLocation: time.UTC, TimeFormats: []string{"01/02/2006", "02/01/2006", "2006-01-02"}}
+	tests := []struct {
+		in   string
+		want []timefy.ParseResult
+	}{
+		{"01/02/2023", []timefy.ParseResult{
+			{Layout: "01/02/2006", Time: utc(2023, time.January, 2, 0, 0, 0)},
+			{Layout: "02/01/2006", Time: utc(2023, time.February, 1, 0, 0, 0)},
+		}},
+		{"01/25/2023", []timefy.ParseResult{{Layout: "01/02/2006", Time: utc(2023, time.January, 25, 0, 0, 0)}}},
+		{"25/01/2023", []timefy.ParseResult{{Layout: "02/01/2006", Time: utc(2023, time.January, 25, 0, 0, 0)}}},
+		{"05/05/2023", []timefy.ParseResult{
+			{Layout: "01/02/2006", Time: utc(2023, time.May, 5, 0, 0, 0)},
+			{Layout: "02/01/2006", Time: utc(2023, time.May, 5, 0, 0, 0)},
+		}},
+		{"2023-10-25", []timefy.ParseResult{{Layout: "2006-01-02", Time: utc(2023, time.October, 25, 0, 0, 0)}}},
+		{"not a date", []timefy.ParseResult{}},
+	}
+	for _, tt := range tests {
+		got := config.ParseMulti(tt.in)
+		if got == nil || len(got) != len(tt.want) {
+			t.Errorf("ParseMulti(%q) = %v, want %v", tt.in, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i].Layout != tt.want[i].Layout || !got[i].Time.Equal(tt.want[i].Time) {
+				t.Errorf("ParseMulti(%q)[%d] = %v, want %v", tt.in, i, got[i], tt.want[i])
+			}
+		}
+	}
+	if got := (&timefy.Config{TimeLocation: time.UTC}).ParseMulti("2023-10-25"); got == nil || len(got) != 0 {
+		t.Errorf("ParseMulti without formats = %v, want an empty slice", got)
+	}
+}
+
+func TestStopwatch(t *testing.T) {
+	clock := &tickClock{current: utc(2023, time.October, 25, 9, 0, 0)}
+	timefy.SetClock(clock)
+	t.Cleanup(func() { timefy.SetClock(nil) })
+	var sw timefy.Stopwatch
+	steps := []struct {
+		name    string
+		advance time.Duration
+		action  func()
+		elapsed time.Duration
+		laps    []time.Duration
+	}{
+		{"idle", time.Minute, func() {}, 0, nil},
+		{"start", 0, sw.Start, 0, nil},
+		{"running", 3 * time.Second, func() {}, 3 * time.Second, nil},
+		{"first lap", 2 * time.Second, func() { sw.Lap() }, 5 * time.Second, []time.Duration{5 * time.Second}},
+		{"second start is ignored", time.Second, sw.Start, 6 * time.Second, []time.Duration{5 * time.Second}},
+		{"stop", 4 * time.Second, sw.Stop, 10 * time.Second, []time.Duration{5 * time.Second}},
+		{"stopped", time.Hour, func() {}, 10 * time.Second, []time.Duration{5 * time.Second}},
+		{"second stop is ignored", time.Minute, sw.Stop, 10 * time.Second, []time.Duration{5 * time.Second}},
+		{"restart", 0, sw.Start, 10 * time.Second, []time.Duration{5 * time.Second}},
+		{"second lap", 1500 * time.Millisecond, func() { sw.Lap() }, 11500 * time.Millisecond, []time.Duration{5 * time.Second, 6500 * time.Millisecond}},
+		{"reset", time.Second, sw.Reset, 0, nil},
+		{"after reset", time.Second, func() {}, 0, nil},
+	}
+	for _, tt := range steps {
+		clock.current = clock.current.Add(tt.advance)
+		tt.action()
+		if got := sw.Elapsed(); got != tt.elapsed {
+			t.Errorf("%s: Elapsed() = %v, want %v", tt.name, got, tt.elapsed)
+		}
+		if got := sw.Laps(); fmt.Sprint(got) != fmt.Sprint(tt.laps) {
+			t.Errorf("%s: Laps() = %v, want %v", tt.name, got, tt.laps)
+		}
+	}
+	sw.Start()
+	clock.current = clock.current.Add(2 * time.Second)
+	if lap := sw.Lap(); lap != 2*time.Second {
+		t.Errorf("Lap() = %v, want 2s", lap)
+	}
+	sw.Laps()[0] = time.Hour
+	if got := sw.Laps(); got[0] != 2*time.Second {
+		t.Errorf("Laps() shares its backing array: %v", got)
+	}
+}
@@ -1,7 +1,9 @@
 package timefy
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -105,9 +107,9 @@ func (c *Config) With(v time.Time) *Timex {
 //	parsedTime, err := config.Parse("2023-10-24T12:00:00") // Parses using the local time zone.
 func (c *Config) Parse(s ...string) (time.Time, error) {
 	if c.TimeLocation == nil {
-		return c.With(time.Now()).Parse(s...)
+		return c.With(now()).Parse(s...)
 	} else {
-		return c.With(time.Now().In(c.TimeLocation)).Parse(s...)
+		return c.With(now().In(c.TimeLocation)).Parse(s...)
 	}
 }
 
@@ -138,331 +140,1457 @@ func (c *Config) Parse(s ...string) (time.Time, error) {
 //	parsedTime := config.MustParse("2023-10-24T12:00:00") // Parses using the local time zone, panicking on failure.
 func (c *Config) MustParse(s ...string) time.Time {
 	if c.TimeLocation == nil {
-		return c.With(time.Now()).MustParse(s...)
+		return c.With(now()).MustParse(s...)
 	} else {
-		return c.With(time.Now().In(c.TimeLocation)).MustParse(s...)
+		return c.With(now().In(c.TimeLocation)).MustParse(s...)
 	}
 }
 
+// ParseInLocation attempts to parse a given set of string representations of time using the formats of the
+// current `Config`, interpreting zone-less inputs in the provided location `loc` for this call only.
+//
+// It behaves like `Parse()`, except that the reference time (used to fill in missing components) and the
+// parsing location come from `loc` instead of the configured `TimeLocation`. The configuration itself is
+// not modified. If `loc` is nil, the function falls back to `Parse()`.
+//
+// Parameters:
+//   - `loc`: A pointer to a time.Location in which zone-less inputs are interpreted.
+//   - `s`: A variadic list of strings representing dates or times to be parsed.
+//
+// Returns:
+//   - A `time.Time` value if one of the provided strings is successfully parsed.
+//   - An error if parsing fails.
+//
+// Example:
+//
+//	config := &Config{TimeFormats: TimeFormats, TimeLocation: time.UTC}
+//	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+//	v, err := config.ParseInLocation(tokyo, "2023-10-25 14:30") // Returns 2023-10-25 14:30:00 +0900 JST.
+//	v, err = config.Parse("2023-10-25 14:30")                   // Still returns 2023-10-25 14:30:00 UTC.
+func (c *Config) ParseInLocation(loc *time.Location, s ...string) (time.Time, error) {
+	if loc == nil {
+		return c.Parse(s...)
+	}
+	return c.With(now().In(loc)).Parse(s...)
+}
+
+// ParseAssumeZone parses the provided string using the current `Config`, interpreting inputs that carry no
+// zone information as wall-clock times in the timezone `assume`.
+//
+// Inputs with an explicit offset or zone (e.g., "2023-10-25T14:30:00+09:00") keep that offset, since the
+// layouts containing zone fields take precedence over the assumed location. Naive inputs such as
+// "2023-10-25 14:30:00" are interpreted in `assume` instead of the configured `TimeLocation` or the
+// local time zone. Missing components (e.g., the date for "14:30") are filled in from the current time
+// in `assume`, as with `Parse()`.
+//
+// Parameters:
+//   - `s`: The string representation of the time to be parsed.
+//   - `assume`: A ZoneRFC value representing the IANA timezone assumed for zone-less inputs (e.g., DefaultTimezoneVietnam).
+//
+// Returns:
+//   - A `time.Time` value if the string is successfully parsed.
+//   - An error if the timezone is invalid or parsing fails.
+//
+// Example:
+//
+//	config := &Config{TimeFormats: TimeFormats}
+//	v, err := config.ParseAssumeZone("2023-10-25 14:30:00", "Asia/Tokyo")      // Returns 2023-10-25 14:30:00 +0900 JST.
+//	v, err = config.ParseAssumeZone("2023-10-25T14:30:00+07:00", "Asia/Tokyo") // Returns 2023-10-25 14:30:00 +0700.
+func (c *Config) ParseAssumeZone(s string, assume ZoneRFC) (time.Time, error) {
+	loc, err := loadLocation(string(assume))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.With(now().In(loc)).Parse(s)
+}
+
+// ParseAny parses the provided string like `Parse()`, using the current `Config`, and also reports which
+// layout of `TimeFormats` matched the input, which helps when debugging ingestion issues.
+//
+// The layouts are tried in the same order and in the same location as `Parse()`: the configured
+// `TimeLocation`, or the location of the current time if it is not set. The first layout that accepts the
+// input is reported, and the returned time is the result of `Parse()`, so components missing from the input
+// are filled in from the current time in the same way.
+//
+// Parameters:
+//   - `s`: The string representation of the time to be parsed.
+//
+// Returns:
+//   - A `time.Time` value if the string is successfully parsed.
+//   - A string containing the layout that matched the input, or an empty string on failure.
+//   - An error if no layout matches, mentioning how many formats were tried.
+//
+// Example:
+//
+//	config := &Config{TimeFormats: TimeFormats}
+//	v, layout, err := config.ParseAny("2023-10-25 14:30") // layout is "2006-1-2 15:4".
+//	v, layout, err = config.ParseAny("20231025")          // layout is "20060102".
+func (c *Config) ParseAny(s string) (time.Time, string, error) {
+	ref := now()
+	if c.TimeLocation != nil {
+		ref = ref.In(c.TimeLocation)
+	}
+	t := c.With(ref)
+	for _, layout := range c.TimeFormats {
+		if _, err := time.ParseInLocation(layout, s, ref.Location()); err == nil {
+			v, err := t.Parse(s)
+			return v, layout, err
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("can't parse string as time: %v (tried %d formats)", s, len(c.TimeFormats))
+}
+
+// ParseFlexible parses a loosely formatted, real-world string (e.g., " 2023-10-25T14:30:00Z\n" or
+// "2023.10.25,14:30") with the current `Config`, normalizing it before running the usual format list.
+//
+// The input is normalized as follows:
+//   - leading and trailing whitespace (spaces, tabs, newlines) is trimmed, and the trimmed string is parsed
+//     first with `Parse()`; if it succeeds, its result is returned as is;
+//   - otherwise, every ',' is replaced with a space and each run of whitespace is collapsed into a single space;
+//   - then, if the string starts with a four-digit year, the '.' and '/' separators of the leading date
+//     (the digits and separators up to the first other character, e.g., the 'T' or space before the time)
+//     are replaced with '-', so "2023.10.25" and "2023/10/25" become "2023-10-25". Separators after the
+//     date, such as the '.' of fractional seconds, are kept;
+//   - the normalized string is parsed with `Parse()`.
+//
+// Parameters:
+//   - `s`: A string representing the date or time to be parsed.
+//
+// Returns:
+//   - A `time.Time` value representing the parsed time.
+//   - An error value, which will be non-nil if neither the trimmed nor the normalized string can be parsed.
+//
+// Example:
+//
+//	config := &Config{TimeLocation: time.UTC, TimeFormats: TimeFormats}
+//	v, err := config.ParseFlexible(" 2023-10-25T14:30:00Z\n") // Returns 2023-10-25 14:30:00 UTC.
+//	v, err = config.ParseFlexible("2023.10.25,14:30")         // Returns 2023-10-25 14:30:00 UTC.
+func (c *Config) ParseFlexible(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	v, err := c.Parse(s)
+	if err != nil {
+		if normalized := normalizeSeparators(s); normalized != s {
+			if nv, nerr := c.Parse(normalized); nerr == nil {
+				return nv, nil
+			}
+		}
+	}
+	return v, err
+}
+
+// ParseWithHint parses the string `s` with the current `Config`, trying the layout `hint` before the
+// configured `TimeFormats`.
+//
+// When most inputs share one known layout, trying it first avoids scanning the whole format list and
+// resolves ambiguous inputs in favor of the hint (e.g., "01/02/2023" is January 2nd with the default
+// formats, but February 1st with the hint "02/01/2006"). The hint is parsed exactly like a layout of
+// `TimeFormats` (missing components are completed as in `Parse()`); only if it fails, or is empty, is the
+// full format list used.
+//
+// Parameters:
+//   - `hint`: A string representing the preferred layout (e.g., "02/01/2006").
+//   - `s`: A string representing the date or time to be parsed.
+//
+// Returns:
+//   - A `time.Time` value representing the parsed time.
+//   - An error value, which will be non-nil if neither the hint nor the configured formats match.
+//
+// Example:
+//
+//	config := &Config{TimeLocation: time.UTC, TimeFormats: TimeFormats}
+//	v, err := config.ParseWithHint("02/01/2006", "01/02/2023") // Returns 2023-02-01 00:00:00 UTC.
+//	v, err = config.Parse("01/02/2023")                        // Returns 2023-01-02 00:00:00 UTC.
+func (c *Config) ParseWithHint(hint string, s string) (time.Time, error) {
+	if hint != "" {
+		hinted := *c
+		hinted.TimeFormats = []string{hint}
+		if v, err := hinted.Parse(s); err == nil {
+			return v, nil
+		}
+	}
+	return c.Parse(s)
+}
+
+// ParseMulti parses the string `s` with every layout of the configured `TimeFormats` and returns all the
+// successful interpretations, in the order of the layouts, so that ambiguous inputs (e.g., "01/02/2023",
+// January 2nd or February 1st) can be resolved by the caller.
+//
+// Each layout is tried on its own exactly as `Parse()` would try it, so missing components are completed
+// in the same way, and the configuration options (e.g., strict mode) apply. Different layouts may yield the
+// same time; every matching layout is reported.
+//
+// Parameters:
+//   - `s`: A string representing the date or time to be parsed.
+//
+// Returns:
+//   - A slice of `ParseResult` values, one per matching layout; it is empty (not nil) when no layout matches.
+//
+// Example:
+//
+//	config := &Config{TimeLocation: time.UTC, TimeFormats: []string{"01/02/2006", "02/01/2006"}}
+//	results := config.ParseMulti("01/02/2023") // Returns 2023-01-02 for "01/02/2006" and 2023-02-01 for "02/01/2006".
+func (c *Config) ParseMulti(s string) []ParseResult {
+	results := []ParseResult{}
+	single := *c
+	for _, layout := range c.TimeFormats {
+		single.TimeFormats = []string{layout}
+		if v, err := single.Parse(s); err == nil {
+			results = append(results, ParseResult{Layout: layout, Time: v})
+		}
+	}
+	return results
+}
+
+// ParseDate parses the string `s` with the current `Config` and returns the start of the parsed calendar
+// day, discarding any time-of-day, for date-only business logic.
+//
+// The string is parsed as with `Parse()`, so both date-only inputs ("2023-10-25") and full timestamps
+// ("2023-10-25 14:30:00") are accepted. The date is taken as written in the string, even when it carries
+// its own offset (e.g., "2023-10-25T23:00:00Z"), and the result is midnight of that date in the configured
+// `TimeLocation` (the local time zone if it is not set). If a DST transition skips midnight, the first
+// instant of the day is returned.
+//
+// Parameters:
+//   - `s`: A string representing the date or timestamp to be parsed.
+//
+// Returns:
+//   - A `time.Time` value representing the start of the parsed day.
+//   - An error value, which will be non-nil if the string cannot be parsed.
+//
+// Example:
+//
+//	config := &Config{TimeLocation: time.UTC, TimeFormats: TimeFormats}
+//	v, err := config.ParseDate("2023-10-25")          // Returns 2023-10-25 00:00:00 UTC.
+//	v, err = config.ParseDate("2023-10-25 14:30:00") // Returns 2023-10-25 00:00:00 UTC.
+func (c *Config) ParseDate(s string) (time.Time, error) {
+	v, err := c.Parse(s)
+	if err != nil {
+		return v, err
+	}
+	loc := c.TimeLocation
+	if loc == nil {
+		loc = time.Local
+	}
+	y, m, d := v.Date()
+	return startOfDay(y, m, d, loc), nil
+}
+
+// Clone returns a copy of the configuration that can be modified without affecting the original.
+//
+// The `TimeFormats` slice is deep-copied, so appending to or editing the formats of the clone never changes
+// the original configuration, nor the package-level `TimeFormats` slice it may share. The location pointer
+// is copied as is, since time.Location values are immutable. Cloning a nil configuration returns nil.
+//
+// Returns:
+//   - A pointer to a new `Config` instance holding the same settings.
+//
+// Example:
+//
+//	base := &Config{WeekStartDay: time.Monday, TimeFormats: TimeFormats}
+//	custom := base.Clone().AppendTimeFormat("02 Jan 2006 15:04") // base is left unchanged.
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	if c.TimeFormats != nil {
+		clone.TimeFormats = append([]string(nil), c.TimeFormats...)
+	}
+	return &clone
+}
+
+// MarshalJSON implements the json.Marshaler interface for Config, so that user time preferences can be persisted.
+//
+// The fields are encoded with their JSON tags, except that:
+//   - `TimeLocation` is encoded as its IANA name (e.g., "Europe/Paris", "UTC" or "Local"), and omitted when nil;
+//   - `WeekStartDay` is encoded as the English name of the day (e.g., "Monday");
+//   - `WeekdayNames` and `MonthNames` are omitted when none of their entries is set.
+//
+// Returns:
+//   - A byte slice containing the JSON object representation of the configuration.
+//   - An error if the value cannot be encoded.
+//
+// Example:
+//
+//	config := &Config{WeekStartDay: time.Monday, TimeLocation: MustLoadLocation("Europe/Paris")}
+//	data, _ := json.Marshal(config) // {"week_start_day":"Monday","time_location":"Europe/Paris"}
+func (c Config) MarshalJSON() ([]byte, error) {
+	day, err := json.Marshal(c.WeekStartDay.String())
+	if err != nil {
+		return nil, err
+	}
+	aux := configJSON{configFields: (*configFields)(&c), WeekStartDay: day}
+	if c.TimeLocation != nil {
+		aux.TimeLocation = c.TimeLocation.String()
+	}
+	if c.WeekdayNames != ([7]string{}) {
+		aux.WeekdayNames = c.WeekdayNames[:]
+	}
+	if c.MonthNames != ([12]string{}) {
+		aux.MonthNames = c.MonthNames[:]
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Config, decoding the form written by `MarshalJSON()`.
+//
+// The time zone name is loaded with time.LoadLocation (through the package location cache), and the name of the
+// first day of the week is matched as in `WithWeekStartDayByName()`; a numeric day (0 for Sunday through 6
+// for Saturday) is also accepted. As with any JSON object, fields missing from the input are left unchanged.
+//
+// Parameters:
+//   - `data`: A byte slice containing the JSON object to decode.
+//
+// Returns:
+//   - An error if the input is not a valid configuration object, the time zone name cannot be loaded, the
+//     day of the week is unknown, or more than 7 weekday names or 12 month names are given.
+//
+// Example:
+//
+//	var config Config
+//	err := json.Unmarshal([]byte(`{"week_start_day":"Monday","time_location":"Europe/Paris"}`), &config)
+func (c *Config) UnmarshalJSON(data []byte) error {
+	aux := configJSON{configFields: (*configFields)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(aux.WeekStartDay) > 0 && string(aux.WeekStartDay) != "null" {
+		var name string
+		if err := json.Unmarshal(aux.WeekStartDay, &name); err == nil {
+			if _, err := c.WithWeekStartDayByName(name); err != nil {
+				return err
+			}
+		} else if err := json.Unmarshal(aux.WeekStartDay, &c.WeekStartDay); err != nil {
+			return err
+		} else if c.WeekStartDay < time.Sunday || c.WeekStartDay > time.Saturday {
+			return fmt.Errorf("unknown week start day: %d", c.WeekStartDay)
+		}
+	}
+	if aux.TimeLocation != "" {
+		loc, err := loadLocation(aux.TimeLocation)
+		if err != nil {
+			return fmt.Errorf("can't load time zone %q: %w", aux.TimeLocation, err)
+		}
+		c.TimeLocation = loc
+	}
+	if len(aux.WeekdayNames) > len(c.WeekdayNames) {
+		return fmt.Errorf("too many weekday names: %d", len(aux.WeekdayNames))
+	}
+	if len(aux.MonthNames) > len(c.MonthNames) {
+		return fmt.Errorf("too many month names: %d", len(aux.MonthNames))
+	}
+	copy(c.WeekdayNames[:], aux.WeekdayNames)
+	copy(c.MonthNames[:], aux.MonthNames)
+	return nil
+}
+
+// AppendTimeFormat adds the provided layouts to the `TimeFormats` of the configuration, and returns the
+// configuration for chaining.
+//
+// The update is copy-on-write: a new slice is always allocated, so the backing array of the previous
+// slice, which may be shared with another configuration or with the package-level `TimeFormats`, is
+// never written to.
+//
+// Parameters:
+//   - `layouts`: The layouts to append, in the order in which they should be tried.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{TimeFormats: TimeFormats}).AppendTimeFormat("02 Jan 2006 15:04")
+//	v, err := config.Parse("25 Oct 2023 14:30")
+func (c *Config) AppendTimeFormat(layouts ...string) *Config {
+	formats := make([]string, 0, len(c.TimeFormats)+len(layouts))
+	formats = append(formats, c.TimeFormats...)
+	c.TimeFormats = append(formats, layouts...)
+	return c
+}
+
+// AppendTimeFormatRFC adds the provided predefined layouts (e.g., TimeFormat20060102150405) to the
+// `TimeFormats` of the configuration, and returns the configuration for chaining.
+//
+// Like `AppendTimeFormat()`, the update is copy-on-write and never mutates a shared slice.
+//
+// Parameters:
+//   - `layouts`: The TimeFormatRFC layouts to append, in the order in which they should be tried.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{TimeFormats: TimeFormats}).AppendTimeFormatRFC(TimeFormat02012006150405)
+func (c *Config) AppendTimeFormatRFC(layouts ...TimeFormatRFC) *Config {
+	formats := make([]string, 0, len(layouts))
+	for _, layout := range layouts {
+		formats = append(formats, string(layout))
+	}
+	return c.AppendTimeFormat(formats...)
+}
+
+// WithExactSeconds sets whether relative descriptions (e.g., `TimeAgo()`, `TimeUntil()`) report spans
+// under a minute in seconds ("30 seconds ago", "in 30 seconds") rather than with the vaguer labels
+// ("just now", "in a few seconds"), and returns the configuration for chaining. Locales that leave the
+// `Second` unit unset keep the vague labels even when the option is enabled.
+//
+// Parameters:
+//   - `v`: A boolean value; true reports exact seconds, false (the default) uses the vague labels.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{}).WithExactSeconds(true)
+//	label := config.With(time.Now().Add(-30 * time.Second)).TimeAgo() // Returns "30 seconds ago".
+func (c *Config) WithExactSeconds(v bool) *Config {
+	c.ExactSeconds = v
+	return c
+}
+
+// WithRejectZero sets whether parsing with the configuration (e.g., `Parse()`, `MustParse()`) fails with
+// `ErrZeroTime` when the result is the zero time.Time, rather than letting a meaningless year-1 value
+// propagate, and returns the configuration for chaining. This covers inputs such as "0001-01-01 00:00:00"
+// as well as calls without any input string. The check is made on the wall clock, so a year-1 midnight parsed
+// in a non-UTC location (which time.Time.IsZero does not report) is rejected as well.
+//
+// Parameters:
+//   - `v`: A boolean value; true rejects zero results, false (the default) accepts them.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{TimeFormats: TimeFormats}).WithRejectZero(true)
+//	_, err := config.Parse("0001-01-01 00:00:00") // Returns ErrZeroTime.
+func (c *Config) WithRejectZero(v bool) *Config {
+	c.RejectZero = v
+	return c
+}
+
+// WithStrict sets whether parsing with the configuration (e.g., `Parse()`, `MustParse()`) is strict, and
+// returns the configuration for chaining.
+//
+// By default, `Parse()` is permissive: strings matching `TimeOnlyRegexp`, i.e., a bare time of day such as
+// "15" (an hour), "13:45", "13:45:30.000" or "3:04 PM", are accepted and completed with the current date.
+// In strict mode, exactly those inputs are rejected with an error, so an ambiguous value like "15" is a hard
+// failure. Every other input, including full timestamps and partial dates such as "2023-08", is parsed
+// exactly as in the default mode.
+//
+// Parameters:
+//   - `v`: A boolean value; true enables strict parsing, false (the default) keeps the permissive behavior.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{TimeFormats: TimeFormats}).WithStrict(true)
+//	_, err := config.Parse("15")                  // Returns an error.
+//	v, err := config.Parse("2023-10-25 15:04:05") // Returns 2023-10-25 15:04:05, nil.
+func (c *Config) WithStrict(v bool) *Config {
+	c.Strict = v
+	return c
+}
+
+// WithInclusiveEnd sets whether the end-of-period helpers of Timex (e.g., `EndOfDay()`, `EndOfMonth()`,
+// `EndOfYear()`) return the last nanosecond of the period (23:59:59.999999999), and returns the configuration
+// for chaining.
+//
+// Inclusive ends are the default, so that every instant of the period, including 23:59:59.5, is before or at
+// its end. Disabling them truncates the ends to the whole second (23:59:59), like the standalone EndOfDayN,
+// for consistency with systems storing second-precision timestamps; instants within the last second of the
+// period then fall after its end. The option is stored inverted, in `TruncateEnd`, so that the zero
+// configuration keeps inclusive ends.
+//
+// Parameters:
+//   - `v`: A boolean value; true (the default) keeps nanosecond ends, false truncates them to the second.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{}).WithInclusiveEnd(false)
+//	end := config.With(time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)).EndOfDay() // Returns 2023-10-25 23:59:59 UTC.
+func (c *Config) WithInclusiveEnd(v bool) *Config {
+	c.TruncateEnd = !v
+	return c
+}
+
+// WithPrecision sets the precision to which `Canonical()` truncates times (e.g., time.Second to drop
+// sub-second noise), and returns the configuration for chaining. Zero, the default, keeps full precision.
+//
+// Parameters:
+//   - `precision`: A time.Duration value representing the precision of canonical times.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{TimeLocation: time.UTC}).WithPrecision(time.Second)
+func (c *Config) WithPrecision(precision time.Duration) *Config {
+	c.Precision = precision
+	return c
+}
+
+// WithCalendarLayouts sets the layouts used by `CalendarLabel()`, and returns the configuration for chaining.
+// An empty layout keeps the default: "15:04" for the time of day and "2006-01-02" for the full date.
+//
+// Parameters:
+//   - `timeLayout`: The layout of the clock time in "Today at 14:30" style labels.
+//   - `dateLayout`: The layout of the labels of times beyond the weekday span (see `WithCalendarWeekdaySpan()`).
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{}).WithCalendarLayouts(time.Kitchen, "Jan 2, 2006")
+//	label := config.With(time.Now()).CalendarLabel() // Returns e.g. "Today at 2:30PM".
+func (c *Config) WithCalendarLayouts(timeLayout, dateLayout string) *Config {
+	c.CalendarTimeLayout = timeLayout
+	c.CalendarDateLayout = dateLayout
+	return c
+}
+
+// WithCalendarWeekdaySpan sets the number of calendar days, before or after today, within which
+// `CalendarLabel()` names the weekday ("Mon at 08:00") rather than printing the full date, and returns the
+// configuration for chaining. Zero or a negative value keeps the default of 6 days, which avoids repeating
+// today's weekday; 1 disables weekday labels altogether.
+//
+// Parameters:
+//   - `days`: The number of days within which weekday labels are used.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{}).WithCalendarWeekdaySpan(3)
+func (c *Config) WithCalendarWeekdaySpan(days int) *Config {
+	c.CalendarWeekdaySpan = days
+	return c
+}
+
+// WithWeekdayNames sets the names returned by `WeekdayName()` and used by `CalendarLabel()`, indexed by
+// time.Weekday (Sunday first), and returns the configuration for chaining. Empty entries fall back to the
+// English name.
+//
+// Parameters:
+//   - `names`: The names of the days, from Sunday to Saturday.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{}).WithWeekdayNames([7]string{"Dimanche", "Lundi", "Mardi", "Mercredi", "Jeudi", "Vendredi", "Samedi"})
+//	name := config.With(time.Date(2023, time.October, 23, 0, 0, 0, 0, time.UTC)).WeekdayName() // Returns "Lundi".
+func (c *Config) WithWeekdayNames(names [7]string) *Config {
+	c.WeekdayNames = names
+	return c
+}
+
+// WithMonthNames sets the names returned by `MonthName()`, from January to December, and returns the
+// configuration for chaining. Empty entries fall back to the English name.
+//
+// Parameters:
+//   - `names`: The names of the months, from January to December.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{}).WithMonthNames([12]string{"Janvier", "Février", "Mars", "Avril", "Mai", "Juin",
+//		"Juillet", "Août", "Septembre", "Octobre", "Novembre", "Décembre"})
+//	name := config.With(time.Date(2023, time.January, 23, 0, 0, 0, 0, time.UTC)).MonthName() // Returns "Janvier".
+func (c *Config) WithMonthNames(names [12]string) *Config {
+	c.MonthNames = names
+	return c
+}
+
+// MondayStart sets the first day of the week of the configuration to Monday (ISO 8601 weeks), and returns
+// the configuration for chaining. It is a shortcut for setting `WeekStartDay` to time.Monday.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{}).MondayStart()
+//	begin := config.With(time.Now()).BeginningOfWeek() // Returns the start of the current week's Monday.
+func (c *Config) MondayStart() *Config {
+	c.WeekStartDay = time.Monday
+	return c
+}
+
+// SundayStart sets the first day of the week of the configuration to Sunday, and returns the configuration
+// for chaining. It is a shortcut for setting `WeekStartDay` to time.Sunday.
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{}).SundayStart()
+//	begin := config.With(time.Now()).BeginningOfWeek() // Returns the start of the current week's Sunday.
+func (c *Config) SundayStart() *Config {
+	c.WeekStartDay = time.Sunday
+	return c
+}
+
+// WithWeekStartDayByName sets the first day of the week of the configuration from the English name of the
+// day, so that it can be read from configuration files without importing the time package, and returns the
+// configuration for chaining.
+//
+// The name is matched case-insensitively, ignoring surrounding whitespace, against the full day names
+// ("monday", "Sunday", "SATURDAY") and their three-letter abbreviations ("mon", "Sun"). Any other name
+// yields an error, and the configuration is left unchanged.
+//
+// Parameters:
+//   - `name`: A string representing the name of the day.
+//
+// Returns:
+//   - A pointer to the same `Config` instance.
+//   - An error value, which will be non-nil if the name is not a known day.
+//
+// Example:
+//
+//	config, err := (&Config{}).WithWeekStartDayByName("monday") // Sets WeekStartDay to time.Monday.
+//	config, err = (&Config{}).WithWeekStartDayByName("funday")  // Returns an error.
+func (c *Config) WithWeekStartDayByName(name string) (*Config, error) {
+	name = strings.TrimSpace(name)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) || strings.EqualFold(name, day.String()[:3]) {
+			c.WeekStartDay = day
+			return c, nil
+		}
+	}
+	return c, fmt.Errorf("unknown week start day: %q", name)
+}
+
+// IsBusinessHoursNow reports whether the current time falls within business hours, using the current `Config`.
+//
+// The current time is read from the package clock (see SetClock) and converted to `TimeLocation` when it is set
+// (the local time zone is used otherwise). Business hours are the daily window [dayStart, dayEnd) on weekdays;
+// Saturdays and Sundays are always outside business hours.
+//
+// Parameters:
+//
+//   - `dayStart`: A ClockTime value representing the opening time (inclusive).
+//   - `dayEnd`: A ClockTime value representing the closing time (exclusive).
+//
+// Returns:
+//   - A boolean value: true if the current time is on a weekday within the daily window; false otherwise.
+//
+// Example:
+//
+//	config := &Config{TimeLocation: time.UTC}
+//	open := config.IsBusinessHoursNow(ClockTime{Hour: 9}, ClockTime{Hour: 17}) // Reports whether the office is open.
+func (c *Config) IsBusinessHoursNow(dayStart, dayEnd ClockTime) bool {
+	current := now()
+	if c.TimeLocation != nil {
+		current = current.In(c.TimeLocation)
+	}
+	if IsWeekend(current) {
+		return false
+	}
+	return !current.Before(clockTimeOn(current, dayStart)) && current.Before(clockTimeOn(current, dayEnd))
+}
+
 // BeginningOfMinute returns a new time.Time value representing the start of the minute for the
 // given Timex instance.
 //
-// The function uses the `Truncate()` method on the underlying time.Time of the Timex struct,
-// truncating the time to the nearest minute. This effectively resets the seconds and
-// nanoseconds to zero, providing the time corresponding to the beginning of the current minute.
+// The function uses the `Truncate()` method on the underlying time.Time of the Timex struct,
+// truncating the time to the nearest minute. This effectively resets the seconds and
+// nanoseconds to zero, providing the time corresponding to the beginning of the current minute.
+//
+// Returns:
+//   - A `time.Time` value representing the start of the minute for the current Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	startOfMinute := t.BeginningOfMinute() // This will return the current time truncated to the start of the minute.
+func (t *Timex) BeginningOfMinute() time.Time {
+	return t.Truncate(time.Minute)
+}
+
+// BeginningOfHour returns a new time.Time value representing the start of the hour for the
+// given Timex instance.
+//
+// The function retrieves the year, month, and day from the underlying time.Time of the Timex struct
+// using the `Date()` method. It then constructs a new time.Time value with the same year, month, and
+// day but sets the hour to the current hour and resets the minutes, seconds, and nanoseconds to zero.
+// This effectively provides the time corresponding to the beginning of the current hour.
+//
+// Returns:
+//   - A `time.Time` value representing the start of the hour for the current Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	startOfHour := t.BeginningOfHour() // This will return the current time truncated to the start of the hour.
+func (t *Timex) BeginningOfHour() time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, t.Time.Hour(), 0, 0, 0, t.Time.Location())
+}
+
+// BeginningOfDay returns a new time.Time value representing the start of the day for the
+// given Timex instance.
+//
+// The function retrieves the year, month, and day from the underlying time.Time of the Timex struct
+// using the `Date()` method. It then constructs a new time.Time value with the same year, month, and
+// day but sets the hour, minute, second, and nanosecond to zero. This effectively resets the time to
+// midnight (00:00:00) of the same date, providing the start of the day.
+//
+// Returns:
+//   - A `time.Time` value representing the start of the day for the current Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	startOfDay := t.BeginningOfDay() // This will return the current date at 00:00:00 (midnight).
+func (t *Timex) BeginningOfDay() time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Time.Location())
+}
+
+// BeginningOfWeek returns a new time.Time value representing the start of the week for the
+//
+//	given Timex instance, based on the configured `WeekStartDay`.
+//
+// The function first calculates the beginning of the current day using `BeginningOfDay()`. Then, it determines
+// the current weekday and calculates how many days to subtract in order to reach the start of the week, based on
+// the configured start day (`WeekStartDay`). If `WeekStartDay` is set to a day other than Sunday, the function
+// adjusts the number of days to move back accordingly.
+//
+// Returns:
+//
+//   - A `time.Time` value representing the start of the week for the current Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now(), Config: &Config{WeekStartDay: time.Monday}}
+//	startOfWeek := t.BeginningOfWeek() // This will return the date and time at the start of the current week.
+//
+// Note:
+//
+//	If `WeekStartDay` is not set (defaults to Sunday), the function will return the preceding Sunday.
+func (t *Timex) BeginningOfWeek() time.Time {
+	day := t.BeginningOfDay()
+	weekday := int(day.Weekday())
+	if t.WeekStartDay != time.Sunday {
+		weekStartDayInt := int(t.WeekStartDay)
+		if weekday < weekStartDayInt {
+			weekday = weekday + 7 - weekStartDayInt
+		} else {
+			weekday = weekday - weekStartDayInt
+		}
+	}
+	return day.AddDate(0, 0, -weekday)
+}
+
+// BeginningOfMonth returns a new time.Time value representing the start of the month for the
+// given Timex instance.
+//
+// The function retrieves the year and month from the underlying time.Time of the Timex struct using the `Date()`
+// method. It then constructs a new time.Time value with the same year and month, but sets the day to the first of
+// the month and resets the hour, minute, second, and nanosecond to zero, providing the start of the month.
+//
+// Returns:
+//   - A `time.Time` value representing the start of the month for the current Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	startOfMonth := t.BeginningOfMonth() // This will return the date and time at the start of the current month.
+func (t *Timex) BeginningOfMonth() time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// BeginningOfQuarter returns a new time.Time value representing the start of the quarter
+// for the given Timex instance.
+//
+// The function first calculates the start of the current month using `BeginningOfMonth()`. It then determines
+// how far the current month is into the quarter by calculating the offset (based on a 3-month quarter system).
+// The offset is subtracted from the current month to return a time value corresponding to the start of the quarter
+// (e.g., if the current month is April, it will return the start of April; if it's June, it will return the start of April).
+//
+// Returns:
+//   - A `time.Time` value representing the start of the quarter for the current Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	startOfQuarter := t.BeginningOfQuarter() // This will return the date and time at the start of the current quarter.
+func (t *Timex) BeginningOfQuarter() time.Time {
+	month := t.BeginningOfMonth()
+	offset := (int(month.Month()) - 1) % 3
+	return month.AddDate(0, -offset, 0)
+}
+
+// BeginningOfHalf returns a new time.Time value representing the start of the half-year
+// for the given Timex instance.
+//
+// The function first calculates the start of the current month using `BeginningOfMonth()`. It then determines
+// how far the current month is into the half-year by calculating the offset (based on a 6-month system).
+// The offset is subtracted from the current month to return a time value corresponding to the start of the half-year
+// (e.g., if the current month is July, it will return the start of July; if it's October, it will return the start of July).
+//
+// Returns:
+//   - A `time.Time` value representing the start of the half-year for the current Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	startOfHalf := t.BeginningOfHalf() // This will return the date and time at the start of the current half-year.
+func (t *Timex) BeginningOfHalf() time.Time {
+	month := t.BeginningOfMonth()
+	offset := (int(month.Month()) - 1) % 6
+	return month.AddDate(0, -offset, 0)
+}
+
+// BeginningOfYear returns a new time.Time value representing the start of the year
+// for the given Timex instance.
+//
+// The function retrieves the year from the underlying time.Time of the Timex struct using the `Date()` method.
+// It then constructs a new time.Time value with the same year but sets the month to January, and the day, hour,
+// minute, second, and nanosecond to zero. This effectively resets the time to midnight (00:00:00) on January 1st
+// of the current year.
+//
+// Returns:
+//   - A `time.Time` value representing the start of the year for the current Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	startOfYear := t.BeginningOfYear() // This will return January 1st of the current year at 00:00:00.
+func (t *Timex) BeginningOfYear() time.Time {
+	y, _, _ := t.Date()
+	return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location())
+}
+
+// BeginningOf returns a new time.Time value representing the start of the given period
+// for the given Timex instance.
+//
+// The function dispatches to the matching `BeginningOf*` method (e.g., `PeriodDay` uses
+// `BeginningOfDay()`, `PeriodWeek` uses `BeginningOfWeek()` and therefore honors the configured
+// `WeekStartDay`). If the period is not recognized, the underlying time is returned unchanged.
+//
+// Parameters:
+//
+//   - `period`: A Period value (e.g., PeriodHour, PeriodDay, PeriodMonth) identifying the calendar unit.
+//
+// Returns:
+//   - A `time.Time` value representing the start of the period for the current Timex instance.
+//
+// Example:
+//
+//	t := With(time.Now())
+//	startOfMonth := t.BeginningOf(PeriodMonth) // Same as t.BeginningOfMonth().
+func (t *Timex) BeginningOf(period Period) time.Time {
+	switch period {
+	case PeriodMinute:
+		return t.BeginningOfMinute()
+	case PeriodHour:
+		return t.BeginningOfHour()
+	case PeriodDay:
+		return t.BeginningOfDay()
+	case PeriodWeek:
+		return t.BeginningOfWeek()
+	case PeriodMonth:
+		return t.BeginningOfMonth()
+	case PeriodQuarter:
+		return t.BeginningOfQuarter()
+	case PeriodHalf:
+		return t.BeginningOfHalf()
+	case PeriodYear:
+		return t.BeginningOfYear()
+	default:
+		return t.Time
+	}
+}
+
+// EndOfMinute returns a new time.Time value representing the end of the current minute
+// for the given Timex instance.
+//
+// The function first calculates the start of the minute using `BeginningOfMinute()`, then adds one minute
+// and subtracts a nanosecond. This adjustment effectively gives the time at the last nanosecond of the current minute.
+//
+// Returns:
+//   - A `time.Time` value representing the end of the current minute for the Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	endOfMinute := t.EndOfMinute() // This will return the time at the end of the current minute (59.999999999 nanoseconds).
+func (t *Timex) EndOfMinute() time.Time {
+	return t.inclusiveEnd(t.BeginningOfMinute().Add(time.Minute - time.Nanosecond))
+}
+
+// EndOfHour returns a new time.Time value representing the end of the current hour
+// for the given Timex instance.
+//
+// The function first calculates the start of the hour using `BeginningOfHour()`, then adds one hour
+// and subtracts a nanosecond to reach the last nanosecond of the current hour.
+//
+// Returns:
+//   - A `time.Time` value representing the end of the current hour for the Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	endOfHour := t.EndOfHour() // This will return the time at the end of the current hour (59:59.999999999).
+func (t *Timex) EndOfHour() time.Time {
+	return t.inclusiveEnd(t.BeginningOfHour().Add(time.Hour - time.Nanosecond))
+}
+
+// EndOfDay returns a new time.Time value representing the end of the current day
+// for the given Timex instance.
+//
+// The function retrieves the year, month, and day from the underlying time.Time of the Timex struct
+// using the `Date()` method. It then constructs a new time.Time value with the same year, month, and day
+// but sets the time to 23:59:59.999999999, which is the last nanosecond of the day. When the configuration
+// disables inclusive ends (see `WithInclusiveEnd()`), the result is truncated to 23:59:59; this applies to
+// all the end-of-period methods.
+//
+// Returns:
+//   - A `time.Time` value representing the end of the current day for the Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now()}
+//	endOfDay := t.EndOfDay() // This will return the date and time at the end of the current day (23:59:59.999999999).
+func (t *Timex) EndOfDay() time.Time {
+	y, m, d := t.Date()
+	return t.inclusiveEnd(time.Date(y, m, d, 23, 59, 59, int(time.Second-time.Nanosecond), t.Location()))
+}
+
+// EndOfWeek returns a new time.Time value representing the end of the current week
+// for the given Timex instance, based on the configured `WeekStartDay`.
+//
+// The function first calculates the start of the current week using `BeginningOfWeek()`,
+// then adds 7 days to reach the start of the next week, and subtracts one nanosecond
+// to get the last nanosecond of the current week.
+//
+// Returns:
+//   - A `time.Time` value representing the end of the current week for the Timex instance.
+//
+// Example:
+//
+//	t := Timex{Time: time.Now(), Config: &Config{WeekStartDay: time.Monday}}
+//	endOfWeek := t.EndOfWeek() // This will return the date and time at the end of the current week.
+//
+// Note:
+//
+//	If `WeekStartDay` is not set (defaults to Sunday), the function will return the preceding Saturday at 23:59:59.999999999.
+func (t *Timex) EndOfWeek() time.Time {
+	return t.inclusiveEnd(t.BeginningOfWeek().AddDate(0, 0, 7).Add(-time.Nanosecond))
+}
+
+// UntilEndOfWeek returns the remaining duration between the time of the given Timex instance
+// and the end of its week, based on the configured `WeekStartDay`.
+//
+// The function computes the end of the week using `EndOfWeek()` and subtracts the underlying time,
+// which makes it convenient for weekly progress indicators.
 //
 // Returns:
-//   - A `time.Time` value representing the start of the minute for the current Timex instance.
+//   - A `time.Duration` value representing the time left until the last nanosecond of the current week.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	startOfMinute := t.BeginningOfMinute() // This will return the current time truncated to the start of the minute.
-func (t *Timex) BeginningOfMinute() time.Time {
-	return t.Truncate(time.Minute)
+//	t := Timex{Time: time.Now(), Config: &Config{WeekStartDay: time.Monday}}
+//	remaining := t.UntilEndOfWeek() // Returns the time left until Sunday 23:59:59.999999999.
+func (t *Timex) UntilEndOfWeek() time.Duration {
+	return t.EndOfWeek().Sub(t.Time)
 }
 
-// BeginningOfHour returns a new time.Time value representing the start of the hour for the
-// given Timex instance.
+// EndOfMonth returns a new time.Time value representing the end of the current month
+// for the given Timex instance.
 //
-// The function retrieves the year, month, and day from the underlying time.Time of the Timex struct
-// using the `Date()` method. It then constructs a new time.Time value with the same year, month, and
-// day but sets the hour to the current hour and resets the minutes, seconds, and nanoseconds to zero.
-// This effectively provides the time corresponding to the beginning of the current hour.
+// The function first calculates the start of the month using `BeginningOfMonth()`,
+// then adds one month to reach the beginning of the next month, and subtracts one nanosecond
+// to obtain the last nanosecond of the current month.
 //
 // Returns:
-//   - A `time.Time` value representing the start of the hour for the current Timex instance.
+//   - A `time.Time` value representing the end of the current month for the Timex instance.
 //
 // Example:
 //
 //	t := Timex{Time: time.Now()}
-//	startOfHour := t.BeginningOfHour() // This will return the current time truncated to the start of the hour.
-func (t *Timex) BeginningOfHour() time.Time {
-	y, m, d := t.Date()
-	return time.Date(y, m, d, t.Time.Hour(), 0, 0, 0, t.Time.Location())
+//	endOfMonth := t.EndOfMonth() // This will return the date and time at the end of the current month (e.g., 23:59:59.999999999 on the last day).
+func (t *Timex) EndOfMonth() time.Time {
+	return t.inclusiveEnd(t.BeginningOfMonth().AddDate(0, 1, 0).Add(-time.Nanosecond))
 }
 
-// BeginningOfDay returns a new time.Time value representing the start of the day for the
-// given Timex instance.
+// EndOfQuarter returns a new time.Time value representing the end of the current quarter
+// for the given Timex instance.
 //
-// The function retrieves the year, month, and day from the underlying time.Time of the Timex struct
-// using the `Date()` method. It then constructs a new time.Time value with the same year, month, and
-// day but sets the hour, minute, second, and nanosecond to zero. This effectively resets the time to
-// midnight (00:00:00) of the same date, providing the start of the day.
+// The function first calculates the start of the quarter using `BeginningOfQuarter()`,
+// then adds three months to reach the beginning of the next quarter, and subtracts one nanosecond
+// to obtain the last nanosecond of the current quarter.
 //
 // Returns:
-//   - A `time.Time` value representing the start of the day for the current Timex instance.
+//   - A `time.Time` value representing the end of the current quarter for the Timex instance.
 //
 // Example:
 //
 //	t := Timex{Time: time.Now()}
-//	startOfDay := t.BeginningOfDay() // This will return the current date at 00:00:00 (midnight).
-func (t *Timex) BeginningOfDay() time.Time {
-	y, m, d := t.Date()
-	return time.Date(y, m, d, 0, 0, 0, 0, t.Time.Location())
+//	endOfQuarter := t.EndOfQuarter() // This will return the date and time at the end of the current quarter (e.g., 23:59:59.999999999 on the last day of the quarter).
+func (t *Timex) EndOfQuarter() time.Time {
+	return t.inclusiveEnd(t.BeginningOfQuarter().AddDate(0, 3, 0).Add(-time.Nanosecond))
 }
 
-// BeginningOfWeek returns a new time.Time value representing the start of the week for the
+// AddQuarters returns a new time.Time value shifted by `n` quarters (three calendar months each) from the
+// time of the given Timex instance. Negative values shift backwards.
 //
-//	given Timex instance, based on the configured `WeekStartDay`.
+// The result keeps the same relative position within the quarter, so shifting by one quarter from any
+// month in Q4 lands in the matching month of Q1 of the next year. Like `AddMonths()`, the day of month is
+// clamped to the last day of the target month (e.g., November 30th + 1 quarter gives February 28th or 29th).
 //
-// The function first calculates the beginning of the current day using `BeginningOfDay()`. Then, it determines
-// the current weekday and calculates how many days to subtract in order to reach the start of the week, based on
-// the configured start day (`WeekStartDay`). If `WeekStartDay` is set to a day other than Sunday, the function
-// adjusts the number of days to move back accordingly.
+// Parameters:
+//   - `n`: The number of quarters to add.
 //
 // Returns:
-//
-//   - A `time.Time` value representing the start of the week for the current Timex instance.
+//   - A `time.Time` value representing the shifted time.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now(), Config: &Config{WeekStartDay: time.Monday}}
-//	startOfWeek := t.BeginningOfWeek() // This will return the date and time at the start of the current week.
+//	t := With(time.Date(2023, time.November, 15, 10, 0, 0, 0, time.UTC))
+//	next := t.AddQuarters(1)  // This will return 2024-02-15 10:00:00 UTC.
+//	prev := t.AddQuarters(-4) // This will return 2022-11-15 10:00:00 UTC.
+func (t *Timex) AddQuarters(n int) time.Time {
+	return addMonthsClamped(t.Time, n*3)
+}
+
+// BeginningOfNextQuarter returns a new time.Time value representing the start of the quarter following
+// the current quarter of the given Timex instance.
 //
-// Note:
+// The function calculates the start of the current quarter using `BeginningOfQuarter()` and adds three months.
 //
-//	If `WeekStartDay` is not set (defaults to Sunday), the function will return the preceding Sunday.
-func (t *Timex) BeginningOfWeek() time.Time {
-	day := t.BeginningOfDay()
-	weekday := int(day.Weekday())
-	if t.WeekStartDay != time.Sunday {
-		weekStartDayInt := int(t.WeekStartDay)
-		if weekday < weekStartDayInt {
-			weekday = weekday + 7 - weekStartDayInt
-		} else {
-			weekday = weekday - weekStartDayInt
-		}
-	}
-	return day.AddDate(0, 0, -weekday)
+// Returns:
+//   - A `time.Time` value representing the first moment of the next quarter.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.November, 15, 10, 0, 0, 0, time.UTC))
+//	begin := t.BeginningOfNextQuarter() // This will return 2024-01-01 00:00:00 UTC.
+func (t *Timex) BeginningOfNextQuarter() time.Time {
+	return t.BeginningOfQuarter().AddDate(0, 3, 0)
 }
 
-// BeginningOfMonth returns a new time.Time value representing the start of the month for the
-// given Timex instance.
+// EndOfPreviousQuarter returns a new time.Time value representing the end of the quarter preceding
+// the current quarter of the given Timex instance.
 //
-// The function retrieves the year and month from the underlying time.Time of the Timex struct using the `Date()`
-// method. It then constructs a new time.Time value with the same year and month, but sets the day to the first of
-// the month and resets the hour, minute, second, and nanosecond to zero, providing the start of the month.
+// The function calculates the start of the current quarter using `BeginningOfQuarter()` and subtracts
+// one nanosecond to obtain the last nanosecond of the previous quarter.
 //
 // Returns:
-//   - A `time.Time` value representing the start of the month for the current Timex instance.
+//   - A `time.Time` value representing the last moment of the previous quarter.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	startOfMonth := t.BeginningOfMonth() // This will return the date and time at the start of the current month.
-func (t *Timex) BeginningOfMonth() time.Time {
-	y, m, _ := t.Date()
-	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+//	t := With(time.Date(2024, time.February, 15, 10, 0, 0, 0, time.UTC))
+//	end := t.EndOfPreviousQuarter() // This will return 2023-12-31 23:59:59.999999999 UTC.
+func (t *Timex) EndOfPreviousQuarter() time.Time {
+	return t.inclusiveEnd(t.BeginningOfQuarter().Add(-time.Nanosecond))
 }
 
-// BeginningOfQuarter returns a new time.Time value representing the start of the quarter
+// EndOfHalf returns a new time.Time value representing the end of the current half-year
 // for the given Timex instance.
 //
-// The function first calculates the start of the current month using `BeginningOfMonth()`. It then determines
-// how far the current month is into the quarter by calculating the offset (based on a 3-month quarter system).
-// The offset is subtracted from the current month to return a time value corresponding to the start of the quarter
-// (e.g., if the current month is April, it will return the start of April; if it's June, it will return the start of April).
+// The function first calculates the start of the half-year using `BeginningOfHalf()`, then adds six months
+// to reach the beginning of the next half-year, and subtracts one nanosecond to obtain the last nanosecond
+// of the current half-year.
 //
 // Returns:
-//   - A `time.Time` value representing the start of the quarter for the current Timex instance.
+//   - A `time.Time` value representing the end of the current half-year for the Timex instance.
 //
 // Example:
 //
 //	t := Timex{Time: time.Now()}
-//	startOfQuarter := t.BeginningOfQuarter() // This will return the date and time at the start of the current quarter.
-func (t *Timex) BeginningOfQuarter() time.Time {
-	month := t.BeginningOfMonth()
-	offset := (int(month.Month()) - 1) % 3
-	return month.AddDate(0, -offset, 0)
+//	endOfHalf := t.EndOfHalf() // This will return the date and time at the end of the current half-year (e.g., 23:59:59.999999999 on June 30th or December 31st).
+func (t *Timex) EndOfHalf() time.Time {
+	return t.inclusiveEnd(t.BeginningOfHalf().AddDate(0, 6, 0).Add(-time.Nanosecond))
 }
 
-// BeginningOfHalf returns a new time.Time value representing the start of the half-year
+// EndOfYear returns a new time.Time value representing the end of the current year
 // for the given Timex instance.
 //
-// The function first calculates the start of the current month using `BeginningOfMonth()`. It then determines
-// how far the current month is into the half-year by calculating the offset (based on a 6-month system).
-// The offset is subtracted from the current month to return a time value corresponding to the start of the half-year
-// (e.g., if the current month is July, it will return the start of July; if it's October, it will return the start of July).
+// The function first calculates the start of the year using `BeginningOfYear()`, then adds one year
+// to reach the beginning of the next year, and subtracts one nanosecond to obtain the last nanosecond
+// of the current year.
 //
 // Returns:
-//   - A `time.Time` value representing the start of the half-year for the current Timex instance.
+//   - A `time.Time` value representing the end of the current year for the Timex instance.
 //
 // Example:
 //
 //	t := Timex{Time: time.Now()}
-//	startOfHalf := t.BeginningOfHalf() // This will return the date and time at the start of the current half-year.
-func (t *Timex) BeginningOfHalf() time.Time {
-	month := t.BeginningOfMonth()
-	offset := (int(month.Month()) - 1) % 6
-	return month.AddDate(0, -offset, 0)
+//	endOfYear := t.EndOfYear() // This will return the date and time at the end of the current year (e.g., 23:59:59.999999999 on December 31st).
+func (t *Timex) EndOfYear() time.Time {
+	return t.inclusiveEnd(t.BeginningOfYear().AddDate(1, 0, 0).Add(-time.Nanosecond))
 }
 
-// BeginningOfYear returns a new time.Time value representing the start of the year
+// BeginningOfDecade returns a new time.Time value representing the start of the decade
 // for the given Timex instance.
 //
-// The function retrieves the year from the underlying time.Time of the Timex struct using the `Date()` method.
-// It then constructs a new time.Time value with the same year but sets the month to January, and the day, hour,
-// minute, second, and nanosecond to zero. This effectively resets the time to midnight (00:00:00) on January 1st
-// of the current year.
+// Decades follow the "2020s" convention: they start in a year divisible by 10, so any date in 2023
+// maps to the decade 2020-2029. The result is January 1st of that year at 00:00:00, in the location
+// of the underlying time.
 //
 // Returns:
-//   - A `time.Time` value representing the start of the year for the current Timex instance.
+//   - A `time.Time` value representing the start of the decade for the Timex instance.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	startOfYear := t.BeginningOfYear() // This will return January 1st of the current year at 00:00:00.
-func (t *Timex) BeginningOfYear() time.Time {
-	y, _, _ := t.Date()
+//	t := With(time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC))
+//	startOfDecade := t.BeginningOfDecade() // This will return 2020-01-01 00:00:00 UTC.
+func (t *Timex) BeginningOfDecade() time.Time {
+	y := t.Year()
+	y -= (y%10 + 10) % 10
 	return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location())
 }
 
-// EndOfMinute returns a new time.Time value representing the end of the current minute
+// EndOfDecade returns a new time.Time value representing the end of the decade
 // for the given Timex instance.
 //
-// The function first calculates the start of the minute using `BeginningOfMinute()`, then adds one minute
-// and subtracts a nanosecond. This adjustment effectively gives the time at the last nanosecond of the current minute.
+// The function calculates the start of the decade using `BeginningOfDecade()`, adds ten years and
+// subtracts one nanosecond, so a date in 2023 gives December 31st, 2029 at 23:59:59.999999999.
 //
 // Returns:
-//   - A `time.Time` value representing the end of the current minute for the Timex instance.
+//   - A `time.Time` value representing the end of the decade for the Timex instance.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	endOfMinute := t.EndOfMinute() // This will return the time at the end of the current minute (59.999999999 nanoseconds).
-func (t *Timex) EndOfMinute() time.Time {
-	return t.BeginningOfMinute().Add(time.Minute - time.Nanosecond)
+//	t := With(time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC))
+//	endOfDecade := t.EndOfDecade() // This will return 2029-12-31 23:59:59.999999999 UTC.
+func (t *Timex) EndOfDecade() time.Time {
+	return t.inclusiveEnd(t.BeginningOfDecade().AddDate(10, 0, 0).Add(-time.Nanosecond))
 }
 
-// EndOfHour returns a new time.Time value representing the end of the current hour
+// BeginningOfCentury returns a new time.Time value representing the start of the century
 // for the given Timex instance.
 //
-// The function first calculates the start of the hour using `BeginningOfHour()`, then adds one hour
-// and subtracts a nanosecond to reach the last nanosecond of the current hour.
+// Centuries follow the "2000s" convention rather than the strict 2001-based one: they start in a year
+// divisible by 100, so any date in 2023 maps to the century 2000-2099. The result is January 1st of
+// that year at 00:00:00, in the location of the underlying time.
 //
 // Returns:
-//   - A `time.Time` value representing the end of the current hour for the Timex instance.
+//   - A `time.Time` value representing the start of the century for the Timex instance.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	endOfHour := t.EndOfHour() // This will return the time at the end of the current hour (59:59.999999999).
-func (t *Timex) EndOfHour() time.Time {
-	return t.BeginningOfHour().Add(time.Hour - time.Nanosecond)
+//	t := With(time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC))
+//	startOfCentury := t.BeginningOfCentury() // This will return 2000-01-01 00:00:00 UTC.
+func (t *Timex) BeginningOfCentury() time.Time {
+	y := t.Year()
+	y -= (y%100 + 100) % 100
+	return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location())
 }
 
-// EndOfDay returns a new time.Time value representing the end of the current day
+// EndOfCentury returns a new time.Time value representing the end of the century
 // for the given Timex instance.
 //
-// The function retrieves the year, month, and day from the underlying time.Time of the Timex struct
-// using the `Date()` method. It then constructs a new time.Time value with the same year, month, and day
-// but sets the time to 23:59:59.999999999, which is the last nanosecond of the day.
+// The function calculates the start of the century using `BeginningOfCentury()`, adds one hundred years
+// and subtracts one nanosecond, so a date in 2023 gives December 31st, 2099 at 23:59:59.999999999.
 //
 // Returns:
-//   - A `time.Time` value representing the end of the current day for the Timex instance.
+//   - A `time.Time` value representing the end of the century for the Timex instance.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC))
+//	endOfCentury := t.EndOfCentury() // This will return 2099-12-31 23:59:59.999999999 UTC.
+func (t *Timex) EndOfCentury() time.Time {
+	return t.inclusiveEnd(t.BeginningOfCentury().AddDate(100, 0, 0).Add(-time.Nanosecond))
+}
+
+// BeginningOfFiscalYear returns a new time.Time value representing the start of the fiscal year
+// containing the given Timex instance, for a fiscal year starting on the first day of `startMonth`.
+//
+// If the current month is before `startMonth`, the fiscal year started in the previous calendar year
+// (e.g., with an April start, a date in March 2024 belongs to the fiscal year starting on April 1st, 2023).
+// A `startMonth` of January (or any value outside January-December) is equivalent to the calendar year.
+//
+// Parameters:
+//   - `startMonth`: A time.Month value representing the first month of the fiscal year.
+//
+// Returns:
+//   - A `time.Time` value representing the first day of the fiscal year at 00:00:00.
+//
+// Example:
+//
+//	t := With(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC))
+//	start := t.BeginningOfFiscalYear(time.April) // Returns 2023-04-01 00:00:00.
+func (t *Timex) BeginningOfFiscalYear(startMonth time.Month) time.Time {
+	if startMonth < time.January || startMonth > time.December {
+		startMonth = time.January
+	}
+	y, m, _ := t.Date()
+	if m < startMonth {
+		y--
+	}
+	return time.Date(y, startMonth, 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfFiscalYear returns a new time.Time value representing the end of the fiscal year
+// containing the given Timex instance, for a fiscal year starting on the first day of `startMonth`.
+//
+// The function first calculates the start of the fiscal year using `BeginningOfFiscalYear()`, then adds
+// one year and subtracts one nanosecond to obtain the last nanosecond of the fiscal year.
+//
+// Parameters:
+//   - `startMonth`: A time.Month value representing the first month of the fiscal year.
+//
+// Returns:
+//   - A `time.Time` value representing the end of the fiscal year (23:59:59.999999999 on its last day).
+//
+// Example:
+//
+//	t := With(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC))
+//	end := t.EndOfFiscalYear(time.April) // Returns 2024-03-31 23:59:59.999999999.
+func (t *Timex) EndOfFiscalYear(startMonth time.Month) time.Time {
+	return t.inclusiveEnd(t.BeginningOfFiscalYear(startMonth).AddDate(1, 0, 0).Add(-time.Nanosecond))
+}
+
+// AtTime returns the date of the Timex instance at the given clock time, keeping its year, month, day and
+// location (e.g., "this date but at 09:00:00").
+//
+// Out-of-range components roll over rather than being rejected: hour=25 is 01:00 on the next day, and
+// negative values roll back to the previous day. See the standalone AtTime function for details.
+//
+// Parameters:
+//   - `hour`: The hour of the day (0-23).
+//   - `min`: The minute of the hour (0-59).
+//   - `sec`: The second of the minute (0-59).
+//   - `nsec`: The nanosecond of the second (0-999999999).
+//
+// Returns:
+//   - A `time.Time` value representing the same date at the requested clock time.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.October, 25, 17, 30, 0, 0, time.UTC))
+//	at := t.AtTime(9, 0, 0, 0) // Returns 2023-10-25 09:00:00.
+//	at = t.AtTime(25, 0, 0, 0) // Returns 2023-10-26 01:00:00.
+func (t *Timex) AtTime(hour, min, sec, nsec int) time.Time {
+	return AtTime(t.Time, hour, min, sec, nsec)
+}
+
+// DiffFrom returns the difference between the time of the Timex instance and `other`, both as the elapsed
+// duration and as a calendar-accurate breakdown, for reporting in a single call.
+//
+// `Duration` is `t.Sub(other)`. The years, months, days, hours, minutes and seconds are computed as in
+// `DiffParts()` (months clamped to the end of shorter months, days counted as calendar days), in the location
+// of the Timex instance, from the earlier time to the later one. The components are therefore always zero or
+// positive, and `Negative` is set when `other` is after the time of the Timex instance.
+//
+// Parameters:
+//   - `other`: The time.Time value to measure from.
+//
+// Returns:
+//   - A `Diff` value holding the duration, its calendar breakdown and its sign.
+//
+// Example:
+//
+//	t := With(time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC))
+//	diff := t.DiffFrom(time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC))
+//	// diff.Months == 1, diff.Days == 1, diff.Hours == 12, diff.Negative == false.
+func (t *Timex) DiffFrom(other time.Time) Diff {
+	diff := Diff{Duration: t.Sub(other), Negative: other.After(t.Time)}
+	from, to := other.In(t.Location()), t.Time
+	if diff.Negative {
+		from, to = t.Time, other
+	}
+	diff.Years, diff.Months, diff.Days, diff.Hours, diff.Minutes, diff.Seconds = DiffParts(from, to)
+	return diff
+}
+
+// Canonical returns the time of the Timex instance in a canonical form suitable for storage and comparison,
+// so that times that are logically equal under the configuration are also equal with ==.
+//
+// The normalization applies the configuration of the Timex instance:
+//   - the time is converted to the configured `TimeLocation`, when it is set;
+//   - the time is truncated to the configured `Precision` (see `WithPrecision()`), when it is positive.
+//     Truncation is performed on the absolute time, so it does not depend on the location;
+//   - the monotonic clock reading, if any, is stripped.
+//
+// Returns:
+//   - A `time.Time` value representing the canonical form of the time.
+//
+// Example:
+//
+//	config := (&Config{TimeLocation: time.UTC}).WithPrecision(time.Second)
+//	a := config.With(time.Date(2023, time.October, 25, 10, 0, 0, 120, time.UTC)).Canonical()
+//	b := config.With(time.Date(2023, time.October, 25, 10, 0, 0, 999, time.UTC)).Canonical()
+//	equal := a == b // Returns true.
+func (t *Timex) Canonical() time.Time {
+	v := t.Time.Round(0)
+	if t.Config == nil {
+		return v
+	}
+	if t.TimeLocation != nil {
+		v = v.In(t.TimeLocation)
+	}
+	if t.Precision > 0 {
+		v = v.Truncate(t.Precision)
+	}
+	return v
+}
+
+// AddMonths returns a new Timex instance, sharing the same configuration, whose time is `n` calendar months
+// after (or before, if `n` is negative) the time of the current Timex instance.
+//
+// Unlike `time.AddDate()`, the day of month is clamped to the last day of the target month instead of
+// overflowing into the next one, so January 31st + 1 month gives February 28th (or 29th in leap years).
+// The clock and location are preserved. Returning a Timex keeps the fluent chaining style of `With()`.
+//
+// Parameters:
+//   - `n`: The number of months to add.
+//
+// Returns:
+//   - A pointer to a new Timex instance holding the shifted time.
+//
+// Example:
+//
+//	t := With(time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC))
+//	next := t.AddMonths(1)              // Holds 2024-02-29 10:00:00 UTC.
+//	begin := next.BeginningOfMonth()    // Returns 2024-02-01 00:00:00 UTC.
+func (t *Timex) AddMonths(n int) *Timex {
+	return &Timex{Time: addMonthsClamped(t.Time, n), Config: t.Config}
+}
+
+// AddYears returns a new Timex instance, sharing the same configuration, whose time is `n` years
+// after (or before, if `n` is negative) the time of the current Timex instance.
+//
+// The day of month is clamped like in `AddMonths()`, so February 29th + 1 year gives February 28th.
+//
+// Parameters:
+//   - `n`: The number of years to add.
+//
+// Returns:
+//   - A pointer to a new Timex instance holding the shifted time.
+//
+// Example:
+//
+//	t := With(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC))
+//	next := t.AddYears(1) // Holds 2025-02-28 00:00:00 UTC.
+func (t *Timex) AddYears(n int) *Timex {
+	return t.AddMonths(n * 12)
+}
+
+// PreviousMonth returns a new Timex instance, sharing the same configuration, whose time is one calendar month
+// before the time of the current Timex instance, preserving the clock time.
+//
+// The day of month is clamped as in `AddMonths()`, so March 31st gives February 28th (or 29th in leap years).
+// Combined with `BeginningOfMonth()`, it reads naturally as "the start of the previous month".
+//
+// Returns:
+//   - A pointer to a new Timex instance holding the shifted time.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	endOfDay := t.EndOfDay() // This will return the date and time at the end of the current day (23:59:59.999999999).
-func (t *Timex) EndOfDay() time.Time {
-	y, m, d := t.Date()
-	return time.Date(y, m, d, 23, 59, 59, int(time.Second-time.Nanosecond), t.Location())
+//	t := With(time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC))
+//	begin := t.PreviousMonth().BeginningOfMonth() // Returns 2023-12-01 00:00:00 UTC.
+func (t *Timex) PreviousMonth() *Timex {
+	return t.AddMonths(-1)
 }
 
-// EndOfWeek returns a new time.Time value representing the end of the current week
-// for the given Timex instance, based on the configured `WeekStartDay`.
+// NextMonth returns a new Timex instance, sharing the same configuration, whose time is one calendar month
+// after the time of the current Timex instance, preserving the clock time.
 //
-// The function first calculates the start of the current week using `BeginningOfWeek()`,
-// then adds 7 days to reach the start of the next week, and subtracts one nanosecond
-// to get the last nanosecond of the current week.
+// The day of month is clamped as in `AddMonths()`, so January 31st gives February 28th (or 29th in leap years).
 //
 // Returns:
-//   - A `time.Time` value representing the end of the current week for the Timex instance.
+//   - A pointer to a new Timex instance holding the shifted time.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now(), Config: &Config{WeekStartDay: time.Monday}}
-//	endOfWeek := t.EndOfWeek() // This will return the date and time at the end of the current week.
-//
-// Note:
-//
-//	If `WeekStartDay` is not set (defaults to Sunday), the function will return the preceding Saturday at 23:59:59.999999999.
-func (t *Timex) EndOfWeek() time.Time {
-	return t.BeginningOfWeek().AddDate(0, 0, 7).Add(-time.Nanosecond)
+//	t := With(time.Date(2023, time.December, 15, 10, 0, 0, 0, time.UTC))
+//	begin := t.NextMonth().BeginningOfMonth() // Returns 2024-01-01 00:00:00 UTC.
+func (t *Timex) NextMonth() *Timex {
+	return t.AddMonths(1)
 }
 
-// EndOfMonth returns a new time.Time value representing the end of the current month
-// for the given Timex instance.
-//
-// The function first calculates the start of the month using `BeginningOfMonth()`,
-// then adds one month to reach the beginning of the next month, and subtracts one nanosecond
-// to obtain the last nanosecond of the current month.
+// PreviousWeek returns a new Timex instance, sharing the same configuration, whose time is seven calendar days
+// before the time of the current Timex instance. The date is shifted with `time.AddDate()`, so the clock time
+// is preserved across DST transitions.
 //
 // Returns:
-//   - A `time.Time` value representing the end of the current month for the Timex instance.
+//   - A pointer to a new Timex instance holding the shifted time.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	endOfMonth := t.EndOfMonth() // This will return the date and time at the end of the current month (e.g., 23:59:59.999999999 on the last day).
-func (t *Timex) EndOfMonth() time.Time {
-	return t.BeginningOfMonth().AddDate(0, 1, 0).Add(-time.Nanosecond)
+//	t := With(time.Date(2024, time.January, 3, 10, 0, 0, 0, time.UTC))
+//	begin := t.PreviousWeek().BeginningOfWeek() // Returns 2023-12-24 00:00:00 UTC (Sunday).
+func (t *Timex) PreviousWeek() *Timex {
+	return &Timex{Time: t.AddDate(0, 0, -7), Config: t.Config}
 }
 
-// EndOfQuarter returns a new time.Time value representing the end of the current quarter
-// for the given Timex instance.
-//
-// The function first calculates the start of the quarter using `BeginningOfQuarter()`,
-// then adds three months to reach the beginning of the next quarter, and subtracts one nanosecond
-// to obtain the last nanosecond of the current quarter.
+// NextWeek returns a new Timex instance, sharing the same configuration, whose time is seven calendar days
+// after the time of the current Timex instance. The date is shifted with `time.AddDate()`, so the clock time
+// is preserved across DST transitions.
 //
 // Returns:
-//   - A `time.Time` value representing the end of the current quarter for the Timex instance.
+//   - A pointer to a new Timex instance holding the shifted time.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	endOfQuarter := t.EndOfQuarter() // This will return the date and time at the end of the current quarter (e.g., 23:59:59.999999999 on the last day of the quarter).
-func (t *Timex) EndOfQuarter() time.Time {
-	return t.BeginningOfQuarter().AddDate(0, 3, 0).Add(-time.Nanosecond)
+//	t := With(time.Date(2023, time.December, 28, 10, 0, 0, 0, time.UTC))
+//	begin := t.NextWeek().BeginningOfWeek() // Returns 2023-12-31 00:00:00 UTC (Sunday).
+func (t *Timex) NextWeek() *Timex {
+	return &Timex{Time: t.AddDate(0, 0, 7), Config: t.Config}
 }
 
-// EndOfHalf returns a new time.Time value representing the end of the current half-year
-// for the given Timex instance.
+// TruncateT returns a new Timex instance, sharing the same configuration, whose time is the time of the current
+// Timex instance rounded down to a multiple of `d`, as `time.Truncate()` does.
 //
-// The function first calculates the start of the half-year using `BeginningOfHalf()`, then adds six months
-// to reach the beginning of the next half-year, and subtracts one nanosecond to obtain the last nanosecond
-// of the current half-year.
+// The embedded `Truncate()` returns a bare time.Time and loses the configuration; TruncateT keeps the fluent
+// chaining style of `With()`. As with `time.Truncate()`, the rounding is performed on the absolute time since
+// the zero time, so for `d` longer than an hour the result may not be aligned to the local wall clock, and a
+// `d` of zero or less leaves the time unchanged (apart from stripping the monotonic clock reading).
+//
+// Parameters:
+//   - `d`: A time.Duration value representing the multiple to truncate to.
 //
 // Returns:
-//   - A `time.Time` value representing the end of the current half-year for the Timex instance.
+//   - A pointer to a new Timex instance holding the truncated time.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	endOfHalf := t.EndOfHalf() // This will return the date and time at the end of the current half-year (e.g., 23:59:59.999999999 on June 30th or December 31st).
-func (t *Timex) EndOfHalf() time.Time {
-	return t.BeginningOfHalf().AddDate(0, 6, 0).Add(-time.Nanosecond)
+//	t := (&Config{WeekStartDay: time.Monday}).With(time.Date(2023, time.October, 25, 10, 42, 0, 0, time.UTC))
+//	begin := t.TruncateT(time.Hour).BeginningOfWeek() // Returns 2023-10-23 00:00:00 UTC (Monday).
+func (t *Timex) TruncateT(d time.Duration) *Timex {
+	return &Timex{Time: t.Time.Truncate(d), Config: t.Config}
 }
 
-// EndOfYear returns a new time.Time value representing the end of the current year
-// for the given Timex instance.
+// RoundT returns a new Timex instance, sharing the same configuration, whose time is the time of the current
+// Timex instance rounded to the nearest multiple of `d` (halfway values round up), as `time.Round()` does.
 //
-// The function first calculates the start of the year using `BeginningOfYear()`, then adds one year
-// to reach the beginning of the next year, and subtracts one nanosecond to obtain the last nanosecond
-// of the current year.
+// The embedded `Round()` returns a bare time.Time and loses the configuration; RoundT keeps the fluent
+// chaining style of `With()`. The same caveats as for `TruncateT()` apply.
+//
+// Parameters:
+//   - `d`: A time.Duration value representing the multiple to round to.
 //
 // Returns:
-//   - A `time.Time` value representing the end of the current year for the Timex instance.
+//   - A pointer to a new Timex instance holding the rounded time.
 //
 // Example:
 //
-//	t := Timex{Time: time.Now()}
-//	endOfYear := t.EndOfYear() // This will return the date and time at the end of the current year (e.g., 23:59:59.999999999 on December 31st).
-func (t *Timex) EndOfYear() time.Time {
-	return t.BeginningOfYear().AddDate(1, 0, 0).Add(-time.Nanosecond)
+//	t := With(time.Date(2023, time.October, 25, 10, 42, 0, 0, time.UTC))
+//	rounded := t.RoundT(time.Hour) // Holds 2023-10-25 11:00:00 UTC.
+func (t *Timex) RoundT(d time.Duration) *Timex {
+	return &Timex{Time: t.Time.Round(d), Config: t.Config}
 }
 
 // Monday returns a new time.Time value representing the most recent Monday at the start of the week
@@ -560,7 +1688,7 @@ func (t *Timex) Sunday(s ...string) time.Time {
 //	t := Timex{Time: time.Now()}
 //	endOfSunday := t.EndOfSunday() // Returns the date and time at the end of the most recent or upcoming Sunday (23:59:59.999999999).
 func (t *Timex) EndOfSunday() time.Time {
-	return New(t.Sunday()).EndOfDay()
+	return t.inclusiveEnd(New(t.Sunday()).EndOfDay())
 }
 
 // Quarter returns the current quarter of the year for the given Timex instance,
@@ -583,6 +1711,62 @@ func (t *Timex) Quarter() uint {
 	return (uint(t.Month())-1)/3 + 1
 }
 
+// WeekOfYear returns the ISO 8601 year and week number of the given Timex instance.
+//
+// ISO weeks start on Monday and week 1 is the week containing the first Thursday of the year, so early
+// January dates may belong to the last week of the previous ISO year and late December dates may belong
+// to week 1 of the next ISO year. The ISO year is therefore returned along with the week number.
+// The `WeekStartDay` configuration does not affect the result.
+//
+// Returns:
+//   - `isoYear`: An integer representing the ISO year the week belongs to.
+//   - `week`: An integer between 1 and 53 representing the ISO week number.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC))
+//	year, week := t.WeekOfYear() // Returns 2022, 52.
+func (t *Timex) WeekOfYear() (isoYear int, week int) {
+	return t.ISOWeek()
+}
+
+// IsLeapDay reports whether the date of the given Timex instance is February 29th.
+//
+// The function checks that the year of the underlying time.Time is a leap year using `IsLeapYear()`
+// and that the month and day are February and 29 respectively. This is useful for special-casing
+// anniversary or birthday logic that falls on a leap day.
+//
+// Returns:
+//   - A boolean value: true if the date is February 29th of a leap year; false otherwise.
+//
+// Example:
+//
+//	t := With(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC))
+//	isLeapDay := t.IsLeapDay() // Returns true.
+func (t *Timex) IsLeapDay() bool {
+	y, m, d := t.Date()
+	return IsLeapYear(y) && m == time.February && d == 29
+}
+
+// Is reports whether the date of the given Timex instance falls on the specified weekday.
+//
+// The weekday is evaluated in the location of the underlying time.Time.
+//
+// Parameters:
+//   - `weekday`: The time.Weekday value to compare with.
+//
+// Returns:
+//   - A boolean value: true if the time falls on `weekday`; false otherwise.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.October, 25, 0, 0, 0, 0, time.UTC)) // Wednesday
+//	ok := t.Is(time.Wednesday) // Returns true.
+//	ok = t.Is(time.Monday)     // Returns false.
+func (t *Timex) Is(weekday time.Weekday) bool {
+	return t.Weekday() == weekday
+}
+
 // Parse interprets the provided date string(s) and converts them into a time.Time value.
 // It attempts to parse each string according to the configured formats, adjusting for the current time
 // and location as necessary.
@@ -613,6 +1797,8 @@ func (t *Timex) Quarter() uint {
 // Note:
 // - The function modifies the parsed date based on the current time when certain components are missing.
 // - It will return the most recent successful parsed value or the zero value of time.Time if none succeed.
+// - If the configuration rejects zero times (see `WithRejectZero()`), a zero result yields `ErrZeroTime`.
+// - In strict mode (see `WithStrict()`), time-only strings yield an error instead of being completed with the current date.
 func (t *Timex) Parse(s ...string) (value time.Time, err error) {
 	var (
 		setCurrentTime  bool
@@ -623,6 +1809,10 @@ func (t *Timex) Parse(s ...string) (value time.Time, err error) {
 	)
 
 	for _, str := range s {
+		if t.Config != nil && t.Strict && TimeOnlyRegexp.MatchString(str) {
+			err = fmt.Errorf("can't parse time-only string in strict mode: %v", str)
+			continue
+		}
 		hasTimeInStr := TimeFormatRegexp.MatchString(str) // match 15:04:05, 15
 		onlyTimeInStr = hasTimeInStr && onlyTimeInStr && TimeOnlyRegexp.MatchString(str)
 		if value, err = t.parseWithFormat(str, currentLocation); err == nil {
@@ -656,6 +1846,9 @@ func (t *Timex) Parse(s ...string) (value time.Time, err error) {
 			currentTime = FormatTimex(value)
 		}
 	}
+	if err == nil && t.Config != nil && t.RejectZero && isZeroWallClock(value) {
+		err = ErrZeroTime
+	}
 	return
 }
 
@@ -718,6 +1911,426 @@ func (t *Timex) Between(begin, end string) bool {
 	return t.After(beginTime) && t.Before(endTime)
 }
 
+// WithJSONFormat sets the layout used to encode and decode Timex values to and from JSON (see
+// `Timex.MarshalJSON()`), and returns the configuration for chaining. An empty layout, the default,
+// uses time.RFC3339Nano.
+//
+// Parameters:
+//   - `layout`: A string representing the time layout (e.g., time.RFC3339 or "2006-01-02 15:04:05").
+//
+// Returns:
+//   - A pointer to the same `Config` instance, updated in place.
+//
+// Example:
+//
+//	config := (&Config{}).WithJSONFormat("2006-01-02")
+//	data, _ := json.Marshal(config.With(time.Date(2023, time.October, 25, 15, 4, 5, 0, time.UTC))) // "2023-10-25"
+func (c *Config) WithJSONFormat(layout string) *Config {
+	c.JSONFormat = layout
+	return c
+}
+
+// MarshalJSON implements the json.Marshaler interface for Timex.
+//
+// Only the wrapped time value is serialized, as a JSON string formatted with the `JSONFormat` layout of the
+// configuration (see `WithJSONFormat()`), or time.RFC3339Nano when it is unset, so that a round trip keeps
+// sub-second precision. The configuration itself is not part of the output.
+//
+// Both JSON methods have pointer receivers: a Timex stored by value in a struct is encoded this way only
+// when the struct is addressable (e.g., json.Marshal(&outer)); use a *Timex field otherwise.
+//
+// Returns:
+//   - A byte slice containing the JSON string representation of the time.
+//   - An error if the value cannot be encoded.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.October, 25, 15, 4, 5, 0, time.UTC))
+//	data, _ := json.Marshal(t) // "2023-10-25T15:04:05Z"
+func (t *Timex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Format(t.jsonFormat()))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Timex.
+//
+// The function decodes a JSON string and parses it with the `JSONFormat` layout of the configuration
+// (time.RFC3339Nano when it is unset, which also accepts RFC3339 values without fractional seconds) first.
+// If that fails, it falls back to the `Parse()` logic, which tries every layout in `TimeFormats`. The
+// configuration of the Timex is kept; a Timex without configuration receives the default configuration,
+// as With() does. A JSON null leaves the Timex unchanged.
+//
+// Parameters:
+//   - `data`: A byte slice containing the JSON value to decode.
+//
+// Returns:
+//   - An error if the value is not a JSON string or cannot be parsed as time.
+//
+// Example:
+//
+//	var t Timex
+//	err := json.Unmarshal([]byte(`"2023-10-25T15:04:05.123Z"`), &t)
+func (t *Timex) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	config := t.Config
+	if config == nil {
+		config = With(now()).Config
+	}
+	v, err := time.Parse(t.jsonFormat(), s)
+	if err != nil {
+		if v, err = config.Parse(s); err != nil {
+			return err
+		}
+	}
+	*t = Timex{Time: v, Config: config}
+	return nil
+}
+
+// BetweenTime checks if the current Timex instance's time falls within the range defined by the
+// provided `start` and `end` time values, boundaries included.
+//
+// Unlike `Between()`, no string parsing is involved. The arguments are treated as an unordered pair:
+// if `start` is after `end`, they are swapped before the comparison.
+//
+// Parameters:
+//   - `start`: A time.Time value representing one bound of the range.
+//   - `end`: A time.Time value representing the other bound of the range.
+//
+// Returns:
+//   - A boolean value indicating whether the time is within [start, end] (inclusive).
+//
+// Example:
+//
+//	t := With(time.Now())
+//	isInRange := t.BetweenTime(time.Now().Add(-time.Hour), time.Now().Add(time.Hour)) // Returns true.
+func (t *Timex) BetweenTime(start, end time.Time) bool {
+	if start.After(end) {
+		start, end = end, start
+	}
+	return !t.Before(start) && !t.After(end)
+}
+
+// TimeAgo returns a human-readable description of how long ago the time of the Timex instance was,
+// relative to the current time, e.g., "just now", "1 minute ago", "5 hours ago" or "2 years ago".
+//
+// It is a shorthand for `TimeAgoLocale(EnglishLocale)`.
+//
+// Returns:
+//   - A string containing the relative time in English.
+//
+// Example:
+//
+//	t := With(time.Now().Add(-5 * time.Minute))
+//	label := t.TimeAgo() // Returns "5 minutes ago".
+func (t *Timex) TimeAgo() string {
+	return t.TimeAgoLocale(EnglishLocale)
+}
+
+// TimeAgoLocale returns a description of how long ago the time of the Timex instance was, relative to
+// the current time, using the labels of the provided locale.
+//
+// Only the largest single unit is reported: minutes, hours, days, weeks (from 7 days up to one calendar month),
+// months or years. Months and years are counted on the calendar (see DiffParts), and spans are reported in
+// months until they reach 24 months, so 45 days gives "1 month", 400 days gives "13 months" and 800 days
+// gives "2 years". The amount is formatted with the singular or plural template of the unit and then
+// wrapped in the `Past` template of the locale. Anything under a minute yields the `JustNow` label, unless
+// the `ExactSeconds` option of the configuration is enabled, in which case spans of at least one second
+// are reported in seconds (e.g., "30 seconds ago"). Times in the future are described as
+// `TimeUntilLocale()` does, wrapped in the `Future` template (e.g., "dans 2 heures"), rather than collapsing to `JustNow`.
+//
+// Parameters:
+//   - `locale`: The Locale holding the label templates.
+//
+// Returns:
+//   - A string containing the relative time in the language of the locale.
+//
+// Example:
+//
+//	fr := Locale{
+//		JustNow: "à l'instant",
+//		Past:    "il y a %s",
+//		Future:  "dans %s",
+//		Minute:  Plural{One: "%d minute", Other: "%d minutes"},
+//		Hour:    Plural{One: "%d heure", Other: "%d heures"},
+//		Day:     Plural{One: "%d jour", Other: "%d jours"},
+//		Week:    Plural{One: "%d semaine", Other: "%d semaines"},
+//		Month:   Plural{One: "%d mois", Other: "%d mois"},
+//		Year:    Plural{One: "%d an", Other: "%d ans"},
+//	}
+//	t := With(time.Now().Add(-3 * time.Hour))
+//	label := t.TimeAgoLocale(fr) // Returns "il y a 3 heures".
+func (t *Timex) TimeAgoLocale(locale Locale) string {
+	current := now()
+	if t.After(current) {
+		return locale.relative(current, t.Time, locale.Future, locale.Soon, t.exactSeconds())
+	}
+	return locale.relative(t.Time, current, locale.Past, locale.JustNow, t.exactSeconds())
+}
+
+// TimeUntil returns a human-readable description of how far in the future the time of the Timex instance is,
+// relative to the current time, e.g., "just now", "in 1 minute" or "in 3 days".
+//
+// It is a shorthand for `TimeUntilLocale(EnglishLocale)`.
+//
+// Returns:
+//   - A string containing the relative time in English.
+//
+// Example:
+//
+//	t := With(time.Now().Add(72 * time.Hour))
+//	label := t.TimeUntil() // Returns "in 3 days".
+//
+//	config := &Config{}
+//	t = config.With(time.Now().Add(30 * time.Second))
+//	label = t.TimeUntil() // Returns "in a few seconds".
+//	config.WithExactSeconds(true)
+//	label = t.TimeUntil() // Returns "in 30 seconds".
+func (t *Timex) TimeUntil() string {
+	return t.TimeUntilLocale(EnglishLocale)
+}
+
+// TimeUntilLocale returns a description of how far in the future the time of the Timex instance is,
+// relative to the current time, using the labels of the provided locale.
+//
+// It is the counterpart of `TimeAgoLocale()`, using the same units and thresholds, and wraps the amount
+// in the `Future` template of the locale. Anything under a minute yields the `Soon` label (e.g., "in a few
+// seconds"), unless the `ExactSeconds` option of the configuration is enabled, in which case spans of at
+// least one second are reported in seconds (e.g., "in 30 seconds"). Under a second, including times in
+// the past, the `JustNow` label is returned.
+//
+// Parameters:
+//   - `locale`: The Locale holding the label templates.
+//
+// Returns:
+//   - A string containing the relative time in the language of the locale.
+//
+// Example:
+//
+//	t := With(time.Now().Add(2 * time.Hour))
+//	label := t.TimeUntilLocale(EnglishLocale) // Returns "in 2 hours".
+func (t *Timex) TimeUntilLocale(locale Locale) string {
+	return locale.relative(now(), t.Time, locale.Future, locale.Soon, t.exactSeconds())
+}
+
+// FormatRelative returns a human-readable description of the time of the Timex instance relative to the
+// current time, choosing the phrasing from the sign of the difference: `TimeAgo()` for past times
+// (e.g., "5 minutes ago") and `TimeUntil()` for future times (e.g., "in 5 minutes").
+//
+// Times within the "just now" window on either side of the current time return "just now": under a minute
+// by default, or under a second when the `ExactSeconds` option of the configuration is enabled.
+//
+// Returns:
+//   - A string containing the relative time in English.
+//
+// Example:
+//
+//	label := With(time.Now().Add(-2 * time.Hour)).FormatRelative()  // Returns "2 hours ago".
+//	label = With(time.Now().Add(2 * time.Hour)).FormatRelative()    // Returns "in 2 hours".
+//	label = With(time.Now().Add(10 * time.Second)).FormatRelative() // Returns "just now".
+func (t *Timex) FormatRelative() string {
+	d := t.Time.Sub(now())
+	if !t.exactSeconds() && d > -time.Minute && d < time.Minute {
+		return EnglishLocale.JustNow
+	}
+	if d > 0 {
+		return t.TimeUntil()
+	}
+	return t.TimeAgo()
+}
+
+// CalendarLabel returns a chat-style label of the time of the Timex instance relative to the current calendar
+// day, in the location of the time, e.g., "Today at 14:30", "Yesterday at 09:15", "Mon at 08:00" or "2023-10-01".
+//
+// The label depends on the number of calendar days (not 24-hour periods) between today and the date of the time:
+//   - the same day gives "Today at <time>", the previous day "Yesterday at <time>" and the next day
+//     "Tomorrow at <time>";
+//   - up to the weekday span (6 days by default) before or after today gives "<weekday> at <time>", with
+//     the weekday name configured in `WeekdayNames` (see `WithWeekdayNames()`), or the abbreviated English
+//     name (e.g., "Mon") when none is configured;
+//   - anything farther gives the full date.
+//
+// The clock layout ("15:04"), the date layout ("2006-01-02") and the weekday span can be overridden in the
+// configuration (see `WithCalendarLayouts()` and `WithCalendarWeekdaySpan()`). The current time is read from
+// the package clock (see SetClock and FreezeTime).
+//
+// Returns:
+//   - A string containing the calendar label in English.
+//
+// Example:
+//
+//	FreezeTime(time.Date(2023, time.October, 25, 10, 0, 0, 0, time.UTC)) // Wednesday
+//	label := With(time.Date(2023, time.October, 25, 14, 30, 0, 0, time.UTC)).CalendarLabel() // Returns "Today at 14:30".
+//	label = With(time.Date(2023, time.October, 23, 8, 0, 0, 0, time.UTC)).CalendarLabel()   // Returns "Mon at 08:00".
+//	label = With(time.Date(2023, time.October, 1, 8, 0, 0, 0, time.UTC)).CalendarLabel()    // Returns "2023-10-01".
+func (t *Timex) CalendarLabel() string {
+	timeLayout, dateLayout, span := t.calendarSettings()
+	ty, tm, td := now().In(t.Location()).Date()
+	y, m, d := t.Date()
+	days := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+	at := " at " + t.Format(timeLayout)
+	switch {
+	case days == 0:
+		return "Today" + at
+	case days == -1:
+		return "Yesterday" + at
+	case days == 1:
+		return "Tomorrow" + at
+	case days >= -span && days <= span:
+		if t.Config != nil && t.WeekdayNames[t.Weekday()] != "" {
+			return t.WeekdayNames[t.Weekday()] + at
+		}
+		return t.Format("Mon") + at
+	default:
+		return t.Format(dateLayout)
+	}
+}
+
+// WeekdayName returns the name of the day of the week of the time of the Timex instance, using the
+// `WeekdayNames` of the configuration (see `WithWeekdayNames()`), e.g., "Lundi" with a French mapping.
+// Without a configured name, the English name ("Monday") is returned.
+//
+// Returns:
+//   - A string containing the name of the day of the week.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.October, 23, 0, 0, 0, 0, time.UTC))
+//	name := t.WeekdayName() // Returns "Monday".
+func (t *Timex) WeekdayName() string {
+	day := t.Weekday()
+	if t.Config != nil && t.WeekdayNames[day] != "" {
+		return t.WeekdayNames[day]
+	}
+	return day.String()
+}
+
+// MonthName returns the name of the month of the time of the Timex instance, using the `MonthNames` of the
+// configuration (see `WithMonthNames()`), e.g., "Janvier" with a French mapping. Without a configured name,
+// the English name ("January") is returned.
+//
+// Returns:
+//   - A string containing the name of the month.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.January, 23, 0, 0, 0, 0, time.UTC))
+//	name := t.MonthName() // Returns "January".
+func (t *Timex) MonthName() string {
+	month := t.Month()
+	if t.Config != nil && t.MonthNames[month-1] != "" {
+		return t.MonthNames[month-1]
+	}
+	return month.String()
+}
+
+// ToEpoch returns the time of the Timex instance as a number of units elapsed since the Unix epoch
+// (January 1, 1970 UTC), which makes config-driven serialization straightforward.
+//
+// The `unit` selects between `Unix()`, `UnixMilli()`, `UnixMicro()` and `UnixNano()` of the underlying
+// time.Time, which remain available on Timex. An unrecognized unit falls back to seconds.
+//
+// Parameters:
+//   - `unit`: An EpochUnit value (EpochSeconds, EpochMillis, EpochMicros or EpochNanos).
+//
+// Returns:
+//   - An int64 value representing the time in the requested unit.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.August, 15, 13, 45, 30, 123456789, time.UTC))
+//	ms := t.ToEpoch(EpochMillis) // Returns 1692107130123.
+func (t *Timex) ToEpoch(unit EpochUnit) int64 {
+	switch unit {
+	case EpochMillis:
+		return t.UnixMilli()
+	case EpochMicros:
+		return t.UnixMicro()
+	case EpochNanos:
+		return t.UnixNano()
+	default:
+		return t.Unix()
+	}
+}
+
+// FormatAs formats the time of the Timex instance with one of the predefined date and time layouts
+// (e.g., TimeFormat20060102150405 for "2006-01-02 15:04:05").
+//
+// Parameters:
+//   - `layout`: A TimeFormatRFC value representing the layout to use.
+//
+// Returns:
+//   - A string containing the formatted time.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.August, 15, 13, 45, 30, 0, time.UTC))
+//	s := t.FormatAs(TimeFormat20060102150405) // Returns "2023-08-15 13:45:30".
+func (t *Timex) FormatAs(layout TimeFormatRFC) string {
+	return t.Format(string(layout))
+}
+
+// FormatShort formats the time of day of the Timex instance with one of the predefined time-only layouts
+// (e.g., TimeRFC01T150405 for "15:04:05").
+//
+// Parameters:
+//   - `layout`: A TimeRFC value representing the layout to use.
+//
+// Returns:
+//   - A string containing the formatted time of day.
+//
+// Example:
+//
+//	t := With(time.Date(2023, time.August, 15, 13, 45, 30, 0, time.UTC))
+//	s := t.FormatShort(TimeRFC07D150405) // Returns "13:45".
+func (t *Timex) FormatShort(layout TimeRFC) string {
+	return t.Format(string(layout))
+}
+
+// TimeAgoShort returns a compact description of how long ago the time of the Timex instance was,
+// relative to the current time, e.g., "5m", "2h", "3d", "1w", "4mo", "13mo" or "2y".
+//
+// Only the largest single unit is reported (90 minutes gives "1h", not "1h30m") and no "ago" suffix is
+// added. Units are minutes, hours, days, weeks (from 7 days up to one calendar month), calendar months
+// (up to 23) and calendar years, as in `TimeAgo()`.
+// Anything under a minute, including times in the future, yields "now".
+//
+// Returns:
+//   - A string containing the compact relative time.
+//
+// Example:
+//
+//	t := With(time.Now().Add(-5 * time.Minute))
+//	label := t.TimeAgoShort() // Returns "5m".
+func (t *Timex) TimeAgoShort() string {
+	return shortSpan(t.Time, now())
+}
+
+// TimeUntilShort returns a compact description of how far in the future the time of the Timex instance is,
+// relative to the current time, e.g., "in 5m", "in 2h" or "in 3d".
+//
+// It is the counterpart of `TimeAgoShort()` and uses the same units and thresholds. Anything under a minute,
+// including times in the past, yields "now".
+//
+// Returns:
+//   - A string containing the compact relative time.
+//
+// Example:
+//
+//	t := With(time.Now().Add(2 * time.Hour))
+//	label := t.TimeUntilShort() // Returns "in 2h".
+func (t *Timex) TimeUntilShort() string {
+	s := shortSpan(now(), t.Time)
+	if s == "now" {
+		return s
+	}
+	return "in " + s
+}
+
 // parseWithFormat attempts to parse a given date/time string `s` using a series of predefined formats
 // specified in the `TimeFormats` slice of the Timex instance. It tries to parse the string in the provided
 // `location` and returns the parsed time value or an error if parsing fails.
@@ -3,10 +3,26 @@ package timefy
 import "time"
 
 // Config configuration for now package
+//
+// The settings are exported fields, so they can be inspected directly (e.g., for logging or debugging)
+// without getters; use Clone for a copy whose TimeFormats can be changed without affecting the original.
 type Config struct {
 	WeekStartDay time.Weekday   `json:"week_start_day,omitempty"`
 	TimeLocation *time.Location `json:"time_location,omitempty"`
 	TimeFormats  []string       `json:"time_formats,omitempty"`
+	JSONFormat   string         `json:"json_format,omitempty"`
+	ExactSeconds bool           `json:"exact_seconds,omitempty"`
+	Precision    time.Duration  `json:"precision,omitempty"`
+	RejectZero   bool           `json:"reject_zero,omitempty"`
+	Strict       bool           `json:"strict,omitempty"`
+	TruncateEnd  bool           `json:"truncate_end,omitempty"`
+
+	CalendarTimeLayout  string `json:"calendar_time_layout,omitempty"`
+	CalendarDateLayout  string `json:"calendar_date_layout,omitempty"`
+	CalendarWeekdaySpan int    `json:"calendar_weekday_span,omitempty"`
+
+	WeekdayNames [7]string  `json:"weekday_names,omitempty"`
+	MonthNames   [12]string `json:"month_names,omitempty"`
 }
 
 // Timex now struct
@@ -14,3 +30,75 @@ type Timex struct {
 	time.Time
 	*Config
 }
+
+// Range time interval struct, from Start to End
+type Range struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// TimeRange alias of Range, for callers manipulating intervals (bookings, availability)
+type TimeRange = Range
+
+// Clock source of the current time used by the package helpers
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockTime time-of-day struct, without date and location
+type ClockTime struct {
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
+	Second int `json:"second"`
+}
+
+// Plural pair of "%d" templates for the singular (One) and plural (Other) forms of a unit,
+// e.g., "%d minute" and "%d minutes"
+type Plural struct {
+	One   string `json:"one"`
+	Other string `json:"other"`
+}
+
+// Locale label templates used to describe relative times, e.g., "just now", "in a few seconds", "%s ago", "in %s"
+type Locale struct {
+	JustNow string `json:"just_now"`
+	Soon    string `json:"soon"`
+	Past    string `json:"past"`
+	Future  string `json:"future"`
+	Second  Plural `json:"second"`
+	Minute  Plural `json:"minute"`
+	Hour    Plural `json:"hour"`
+	Day     Plural `json:"day"`
+	Week    Plural `json:"week"`
+	Month   Plural `json:"month"`
+	Year    Plural `json:"year"`
+}
+
+// Diff difference between two times, as an elapsed duration and as a calendar breakdown
+// (non-negative components, with the sign carried by Negative)
+type Diff struct {
+	Duration time.Duration `json:"duration"`
+	Years    int           `json:"years"`
+	Months   int           `json:"months"`
+	Days     int           `json:"days"`
+	Hours    int           `json:"hours"`
+	Minutes  int           `json:"minutes"`
+	Seconds  int           `json:"seconds"`
+	Negative bool          `json:"negative"`
+}
+
+// ParseResult successful interpretation of a string, with the layout that matched it
+type ParseResult struct {
+	Layout string    `json:"layout"`
+	Time   time.Time `json:"time"`
+}
+
+// Stopwatch elapsed-time measurement with laps, reading the package clock (see SetClock).
+// The zero value is a stopped stopwatch at zero; it is not safe for concurrent use
+type Stopwatch struct {
+	running bool
+	started time.Time       // instant of the last Start, while running
+	elapsed time.Duration   // time accumulated by the previous runs
+	lapMark time.Duration   // elapsed time when the last lap was recorded
+	laps    []time.Duration // recorded lap durations
+}